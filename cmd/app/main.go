@@ -54,6 +54,18 @@ func main() {
 		}
 	}
 
+	// SIGHUP reloads the URL rules file without a restart
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+	go func() {
+		for range reload {
+			if err := a.ReloadURLRules(); err != nil {
+				log.Printf("reload url rules: %v", err)
+			}
+		}
+	}()
+
 	var wg sync.WaitGroup
 	srvErr := make(chan error, 2)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"image/png"
 	"log/slog"
 	"net"
 	"net/http"
@@ -18,7 +19,9 @@ import (
 	healthHTTPHandler "github.com/OsoianMarcel/url-shortener/internal/delivery/http/handler/health"
 	shortHTTPHandler "github.com/OsoianMarcel/url-shortener/internal/delivery/http/handler/short"
 	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/middleware"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
 	"github.com/OsoianMarcel/url-shortener/internal/infra"
+	"github.com/OsoianMarcel/url-shortener/pkg/qrimage"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -68,16 +71,46 @@ func (a *app) init(ctx context.Context) error {
 		return fmt.Errorf("ensure mongodb indexes: %w", err)
 	}
 
+	if err := infra.EnsureClickEventIndexes(ctx, a.logger, a.mongoClient); err != nil {
+		return fmt.Errorf("ensure mongodb click event indexes: %w", err)
+	}
+
+	if err := infra.EnsureLinkRevisionIndexes(ctx, a.logger, a.mongoClient); err != nil {
+		return fmt.Errorf("ensure mongodb link revision indexes: %w", err)
+	}
+
+	if err := infra.EnsureAPIKeyIndexes(ctx, a.logger, a.mongoClient); err != nil {
+		return fmt.Errorf("ensure mongodb api key indexes: %w", err)
+	}
+
 	a.redisClient, err = initRedis(ctx, conf.Redis)
 	if err != nil {
 		return fmt.Errorf("init redis: %w", err)
 	}
 
+	tokenVerifier, err := initTokenVerifier(a.logger, conf.JWT)
+	if err != nil {
+		return fmt.Errorf("init jwt verifier: %w", err)
+	}
+
+	urlRulesPolicy, err := initURLRulesPolicy(a.logger, conf.Business)
+	if err != nil {
+		return fmt.Errorf("init url rules: %w", err)
+	}
+
+	qrRenderer, err := initQRRenderer(conf.Business)
+	if err != nil {
+		return fmt.Errorf("init qr renderer: %w", err)
+	}
+
 	a.serviceProvider = newServiceProvider(
 		a.logger,
 		conf,
 		a.mongoClient,
 		a.redisClient,
+		tokenVerifier,
+		urlRulesPolicy,
+		qrRenderer,
 	)
 
 	a.httpServer = initHTTPServer(a.serviceProvider)
@@ -118,8 +151,18 @@ func (a *app) ServeGRPC(ctx context.Context) error {
 // from the DI container, and runs the CLI against the provided args.
 func (a *app) ServeCLI(ctx context.Context, args []string) error {
 	shortCmd := command.NewShortCommand(a.serviceProvider.getShortLinkUsecase())
+	keysCmd := command.NewKeysCommand(a.serviceProvider.getAPIKeyUsecase())
 
-	return cli.Run(ctx, args, os.Stdout, os.Stderr, shortCmd)
+	return cli.Run(ctx, args, os.Stdout, os.Stderr, shortCmd, keysCmd)
+}
+
+// ReloadURLRules reads the URL rules file again, it does nothing when no file is configured.
+func (a *app) ReloadURLRules() error {
+	if a.serviceProvider.urlRulesPolicy == nil {
+		return nil
+	}
+
+	return a.serviceProvider.urlRulesPolicy.Reload()
 }
 
 func (a *app) Shutdown(ctx context.Context) error {
@@ -148,6 +191,29 @@ func (a *app) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// the servers are stopped, so no new click events can be queued
+	if a.serviceProvider != nil && a.serviceProvider.clickEventRecorder != nil {
+		err = a.serviceProvider.clickEventRecorder.Close(ctx)
+		if err != nil {
+			allErr = errors.Join(allErr, fmt.Errorf("close click event recorder: %w", err))
+		}
+	}
+
+	if a.serviceProvider != nil && a.serviceProvider.trashPurger != nil {
+		err = a.serviceProvider.trashPurger.Close(ctx)
+		if err != nil {
+			allErr = errors.Join(allErr, fmt.Errorf("stop trash purger: %w", err))
+		}
+	}
+
+	// the buffered hits need both MongoDB and Redis, so they are flushed before disconnecting
+	if a.serviceProvider != nil && a.serviceProvider.hitsFlusher != nil {
+		err = a.serviceProvider.hitsFlusher.Close(ctx)
+		if err != nil {
+			allErr = errors.Join(allErr, fmt.Errorf("flush pending hits: %w", err))
+		}
+	}
+
 	if a.mongoClient != nil {
 		err = a.mongoClient.Disconnect(ctx)
 		if err != nil {
@@ -177,8 +243,21 @@ func initHTTPServer(sp *serviceProvider) *http.Server {
 		mux,
 		sp.logger,
 		sp.getShortLinkUsecase(),
-		sp.config.Http.APISecret,
-		sp.config.Business.LinkNotFoundRedirectURL,
+		sp.getAuthUsecase(),
+		sp.getRateLimiter(),
+		shortHTTPHandler.RateLimits{
+			Create:   domain.RateLimit(sp.config.RateLimit.Create),
+			Bulk:     domain.RateLimit(sp.config.RateLimit.Bulk),
+			Redirect: domain.RateLimit(sp.config.RateLimit.Redirect),
+			Preview:  domain.RateLimit(sp.config.RateLimit.Preview),
+			Expand:   domain.RateLimit(sp.config.RateLimit.Expand),
+			Stats:    domain.RateLimit(sp.config.RateLimit.Stats),
+			QR:       domain.RateLimit(sp.config.RateLimit.QR),
+		},
+		newNotFoundRedirects(sp.config.Business),
+		sp.config.Http.TrustProxy,
+		sp.config.Business.LinkPreview,
+		shortHTTPHandler.DisabledLinkResponse(sp.config.Business.DisabledLinkResponse),
 	)
 
 	// Health handlers.
@@ -193,6 +272,7 @@ func initHTTPServer(sp *serviceProvider) *http.Server {
 			commonHTTPHandler.PreflightHandler(mux),
 			middleware.LoggingMiddleware(sp.logger),
 			middleware.RecoverMiddleware(sp.logger),
+			middleware.TransportMiddleware(),
 		),
 		ReadHeaderTimeout: 2 * time.Second,
 		ReadTimeout:       5 * time.Second,
@@ -206,12 +286,80 @@ func initHTTPServer(sp *serviceProvider) *http.Server {
 func initGRPCServer(sp *serviceProvider) *gogrpc.Server {
 	return grpcdelivery.NewServer(
 		sp.logger,
-		sp.config.Http.APISecret,
+		sp.getAuthUsecase(),
+		sp.getRateLimiter(),
+		grpcdelivery.RateLimits{
+			Create: domain.RateLimit(sp.config.RateLimit.Create),
+			Bulk:   domain.RateLimit(sp.config.RateLimit.Bulk),
+			Expand: domain.RateLimit(sp.config.RateLimit.Expand),
+			Stats:  domain.RateLimit(sp.config.RateLimit.Stats),
+			QR:     domain.RateLimit(sp.config.RateLimit.QR),
+		},
 		sp.getShortLinkUsecase(),
 		sp.getHealthUsecase(),
+		sp.config.Grpc.TrustProxy,
 	)
 }
 
+// newNotFoundRedirects maps the short domains to their not found URLs,
+// the unknown hosts are served as the primary domain so they use its URL.
+func newNotFoundRedirects(businessConfig *config.BusinessConfig) shortHTTPHandler.NotFoundRedirects {
+	redirects := shortHTTPHandler.NotFoundRedirects{
+		Default:  businessConfig.ShortDomains[0].LinkNotFoundRedirectURL,
+		ByDomain: make(map[string]string, len(businessConfig.ShortDomains)),
+	}
+	for _, shortDomain := range businessConfig.ShortDomains {
+		redirects.ByDomain[shortDomain.Host] = shortDomain.LinkNotFoundRedirectURL
+	}
+
+	return redirects
+}
+
+// initTokenVerifier returns nil when no JWKS is configured, so only the API keys are accepted.
+func initTokenVerifier(logger *slog.Logger, jwtConfig *config.JWTConfig) (domain.TokenVerifier, error) {
+	if !jwtConfig.Enabled() {
+		return nil, nil
+	}
+
+	return infra.NewJWTVerifier(logger, infra.JWTVerifierOptions{
+		JWKSFile:   jwtConfig.JWKSFile,
+		JWKSURL:    jwtConfig.JWKSURL,
+		Issuer:     jwtConfig.Issuer,
+		Audience:   jwtConfig.Audience,
+		ScopeClaim: jwtConfig.ScopeClaim,
+		OwnerClaim: jwtConfig.OwnerClaim,
+	})
+}
+
+// initURLRulesPolicy returns nil when no URL rules file is configured.
+func initURLRulesPolicy(logger *slog.Logger, businessConfig *config.BusinessConfig) (*infra.URLRulesPolicy, error) {
+	if businessConfig.URLRulesFile == "" {
+		return nil, nil
+	}
+
+	return infra.NewURLRulesPolicy(logger, businessConfig.URLRulesFile)
+}
+
+// initQRRenderer returns a renderer without a logo when no logo file is configured.
+func initQRRenderer(businessConfig *config.BusinessConfig) (*qrimage.Renderer, error) {
+	if businessConfig.QRLogoFile == "" {
+		return qrimage.New(nil)
+	}
+
+	file, err := os.Open(businessConfig.QRLogoFile)
+	if err != nil {
+		return nil, fmt.Errorf("open logo: %w", err)
+	}
+	defer file.Close()
+
+	logo, err := png.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("decode logo: %w", err)
+	}
+
+	return qrimage.New(logo)
+}
+
 func initLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{AddSource: false}))
 }
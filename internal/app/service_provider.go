@@ -1,13 +1,16 @@
 package app
 
 import (
-	"fmt"
 	"log/slog"
+	"net/url"
+	"strings"
 
 	"github.com/OsoianMarcel/url-shortener/internal/config"
 	"github.com/OsoianMarcel/url-shortener/internal/domain"
 	"github.com/OsoianMarcel/url-shortener/internal/infra"
 	"github.com/OsoianMarcel/url-shortener/internal/usecase"
+	"github.com/OsoianMarcel/url-shortener/pkg/botdetect"
+	"github.com/OsoianMarcel/url-shortener/pkg/qrimage"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -18,10 +21,26 @@ type serviceProvider struct {
 	config      *config.Config
 	mongoClient *mongo.Client
 	redisClient *redis.Client
+	// nil when JWT authentication is disabled
+	tokenVerifier domain.TokenVerifier
+	// nil when no URL rules file is configured
+	urlRulesPolicy *infra.URLRulesPolicy
+	qrRenderer     *qrimage.Renderer
 	// initialized providers
-	shortLinkRep     domain.ShortLinkRepo
-	shortLinkUsecase domain.ShortLinkUsecase
-	healthUsecase    domain.HealthUsecase
+	shortLinkRep       domain.ShortLinkRepo
+	hitsFlusher        *infra.ShortLinkHitsFlusher
+	trashPurger        *infra.ShortLinkTrashPurger
+	clickEventRepo     domain.ClickEventRepo
+	uniqueVisitorRepo  domain.UniqueVisitorRepo
+	passwordAttemptRep domain.PasswordAttemptRepo
+	linkRevisionRepo   domain.LinkRevisionRepo
+	clickEventRecorder *infra.ClickEventRecorder
+	shortLinkUsecase   domain.ShortLinkUsecase
+	apiKeyRepo         domain.APIKeyRepo
+	apiKeyUsecase      domain.APIKeyUsecase
+	authUsecase        domain.AuthUsecase
+	rateLimiter        domain.RateLimiter
+	healthUsecase      domain.HealthUsecase
 }
 
 func newServiceProvider(
@@ -29,12 +48,18 @@ func newServiceProvider(
 	config *config.Config,
 	mongoClient *mongo.Client,
 	redisClient *redis.Client,
+	tokenVerifier domain.TokenVerifier,
+	urlRulesPolicy *infra.URLRulesPolicy,
+	qrRenderer *qrimage.Renderer,
 ) *serviceProvider {
 	return &serviceProvider{
-		logger:      logger,
-		config:      config,
-		mongoClient: mongoClient,
-		redisClient: redisClient,
+		logger:         logger,
+		config:         config,
+		mongoClient:    mongoClient,
+		redisClient:    redisClient,
+		tokenVerifier:  tokenVerifier,
+		urlRulesPolicy: urlRulesPolicy,
+		qrRenderer:     qrRenderer,
 	}
 }
 
@@ -47,29 +72,219 @@ func (sp *serviceProvider) getShortRepo() domain.ShortLinkRepo {
 		sp.logger,
 		sp.mongoClient,
 		sp.redisClient,
+		sp.config.Hits.WriteBehind,
 	)
 
+	// the buffered hits are written to MongoDB only while the flusher runs
+	if sp.config.Hits.WriteBehind {
+		sp.getHitsFlusher()
+	}
+
+	// the deleted links are removed only while the purger runs
+	if sp.config.Trash.PurgeAfter > 0 {
+		sp.getTrashPurger()
+	}
+
 	return sp.shortLinkRep
 }
 
+func (sp *serviceProvider) getHitsFlusher() *infra.ShortLinkHitsFlusher {
+	if sp.hitsFlusher != nil {
+		return sp.hitsFlusher
+	}
+
+	sp.hitsFlusher = infra.NewShortLinkHitsFlusher(
+		sp.logger,
+		sp.mongoClient,
+		sp.redisClient,
+		sp.config.Hits.FlushInterval,
+	)
+
+	return sp.hitsFlusher
+}
+
+func (sp *serviceProvider) getTrashPurger() *infra.ShortLinkTrashPurger {
+	if sp.trashPurger != nil {
+		return sp.trashPurger
+	}
+
+	sp.trashPurger = infra.NewShortLinkTrashPurger(
+		sp.logger,
+		sp.getShortRepo(),
+		sp.config.Trash.PurgeAfter,
+		sp.config.Trash.PurgeInterval,
+	)
+
+	return sp.trashPurger
+}
+
+func (sp *serviceProvider) getClickEventRepo() domain.ClickEventRepo {
+	if sp.clickEventRepo != nil {
+		return sp.clickEventRepo
+	}
+
+	sp.clickEventRepo = infra.NewClickEventRepository(sp.logger, sp.mongoClient)
+
+	return sp.clickEventRepo
+}
+
+func (sp *serviceProvider) getUniqueVisitorRepo() domain.UniqueVisitorRepo {
+	if sp.uniqueVisitorRepo != nil {
+		return sp.uniqueVisitorRepo
+	}
+
+	sp.uniqueVisitorRepo = infra.NewUniqueVisitorRepository(sp.logger, sp.redisClient)
+
+	return sp.uniqueVisitorRepo
+}
+
+func (sp *serviceProvider) getPasswordAttemptRepo() domain.PasswordAttemptRepo {
+	if sp.passwordAttemptRep != nil {
+		return sp.passwordAttemptRep
+	}
+
+	sp.passwordAttemptRep = infra.NewPasswordAttemptRepository(sp.logger, sp.redisClient)
+
+	return sp.passwordAttemptRep
+}
+
+func (sp *serviceProvider) getLinkRevisionRepo() domain.LinkRevisionRepo {
+	if sp.linkRevisionRepo != nil {
+		return sp.linkRevisionRepo
+	}
+
+	sp.linkRevisionRepo = infra.NewLinkRevisionRepository(sp.logger, sp.mongoClient)
+
+	return sp.linkRevisionRepo
+}
+
+func (sp *serviceProvider) getClickEventRecorder() *infra.ClickEventRecorder {
+	if sp.clickEventRecorder != nil {
+		return sp.clickEventRecorder
+	}
+
+	sp.clickEventRecorder = infra.NewClickEventRecorder(sp.logger, sp.getClickEventRepo())
+
+	return sp.clickEventRecorder
+}
+
 func (sp *serviceProvider) getShortLinkUsecase() domain.ShortLinkUsecase {
 	if sp.shortLinkUsecase != nil {
 		return sp.shortLinkUsecase
 	}
 
-	buildShortURL := func(key string) string {
-		return fmt.Sprintf("%s/api/shortener/%s/redirect", sp.config.Business.BaseURL, key)
+	buildShortURL := func(host string, key string) string {
+		return strings.NewReplacer("{domain}", host, "{key}", key).Replace(sp.config.Business.ShortURLTemplate)
+	}
+
+	shortDomains := sp.config.Business.ShortDomains
+	domains := usecase.Domains{Primary: shortDomains[0].Host}
+	for _, shortDomain := range shortDomains[1:] {
+		domains.Others = append(domains.Others, shortDomain.Host)
+	}
+
+	botPatterns := sp.config.Business.BotUserAgentPatterns
+	if botPatterns == nil {
+		botPatterns = botdetect.DefaultPatterns
 	}
 
 	sp.shortLinkUsecase = usecase.NewShortLinkUsecase(
 		sp.logger,
 		sp.getShortRepo(),
+		sp.getClickEventRepo(),
+		sp.getUniqueVisitorRepo(),
+		sp.getPasswordAttemptRepo(),
+		sp.getLinkRevisionRepo(),
+		sp.getClickEventRecorder(),
+		botdetect.New(botPatterns),
+		sp.qrRenderer,
+		sp.getURLPolicy(),
+		usecase.RedirectOptions{
+			DefaultStatus:   sp.config.Business.RedirectStatus,
+			PermanentMaxAge: sp.config.Business.PermanentRedirectMaxAge,
+		},
+		domains,
 		buildShortURL,
 	)
 
 	return sp.shortLinkUsecase
 }
 
+func (sp *serviceProvider) getURLPolicy() domain.URLPolicy {
+	shortenerDomains := sp.config.Business.ShortenerDomains
+	if shortenerDomains == nil {
+		shortenerDomains = usecase.DefaultShortenerDomains
+	}
+	// links to this service would redirect to themselves
+	for _, serviceURL := range []string{sp.config.Business.BaseURL, sp.config.Business.ShortURLTemplate} {
+		if parsedURL, err := url.Parse(serviceURL); err == nil && parsedURL.Hostname() != "" {
+			shortenerDomains = append([]string{parsedURL.Hostname()}, shortenerDomains...)
+		}
+	}
+	for _, shortDomain := range sp.config.Business.ShortDomains {
+		shortenerDomains = append([]string{(&url.URL{Host: shortDomain.Host}).Hostname()}, shortenerDomains...)
+	}
+
+	policies := []domain.URLPolicy{
+		usecase.NewMaxLengthPolicy(sp.config.Business.URLMaxLength),
+		usecase.NewPrivateAddressPolicy(),
+		usecase.NewShortenerPolicy(shortenerDomains),
+	}
+	if sp.urlRulesPolicy != nil {
+		policies = append(policies, sp.urlRulesPolicy)
+	}
+
+	return usecase.NewURLPolicy(policies...)
+}
+
+func (sp *serviceProvider) getAPIKeyRepo() domain.APIKeyRepo {
+	if sp.apiKeyRepo != nil {
+		return sp.apiKeyRepo
+	}
+
+	sp.apiKeyRepo = infra.NewAPIKeyRepository(sp.logger, sp.mongoClient)
+
+	return sp.apiKeyRepo
+}
+
+func (sp *serviceProvider) getAPIKeyUsecase() domain.APIKeyUsecase {
+	if sp.apiKeyUsecase != nil {
+		return sp.apiKeyUsecase
+	}
+
+	sp.apiKeyUsecase = usecase.NewAPIKeyUsecase(
+		sp.logger,
+		sp.getAPIKeyRepo(),
+	)
+
+	return sp.apiKeyUsecase
+}
+
+func (sp *serviceProvider) getAuthUsecase() domain.AuthUsecase {
+	if sp.authUsecase != nil {
+		return sp.authUsecase
+	}
+
+	sp.authUsecase = usecase.NewAuthUsecase(
+		sp.logger,
+		sp.getAPIKeyRepo(),
+		sp.tokenVerifier,
+		sp.config.Http.APISecret,
+	)
+
+	return sp.authUsecase
+}
+
+func (sp *serviceProvider) getRateLimiter() domain.RateLimiter {
+	if sp.rateLimiter != nil {
+		return sp.rateLimiter
+	}
+
+	sp.rateLimiter = infra.NewRateLimiter(sp.logger, sp.redisClient)
+
+	return sp.rateLimiter
+}
+
 func (sp *serviceProvider) getHealthUsecase() domain.HealthUsecase {
 	if sp.healthUsecase != nil {
 		return sp.healthUsecase
@@ -2,13 +2,46 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// ShortDomain is a domain serving the short links, the keys are unique per domain.
+type ShortDomain struct {
+	// Lowercase host, with the port when it is not the default one
+	Host string
+	// Where the visitors of the unknown links of the domain are redirected
+	LinkNotFoundRedirectURL string
+}
+
 type BusinessConfig struct {
 	BaseURL                 string
 	LinkNotFoundRedirectURL string
+	// Case-insensitive user agent substrings of bots, nil means the default list
+	BotUserAgentPatterns []string
+	// Longest destination URL accepted, in bytes
+	URLMaxLength int
+	// Optional file of allow and deny domain rules for the destination URLs, reloaded on SIGHUP
+	URLRulesFile string
+	// Domains of other URL shorteners which cannot be destinations, nil means the default list
+	ShortenerDomains []string
+	// Status code of the redirects of the links without their own: 301, 302, 307 or 308
+	RedirectStatus int
+	// Longest time the browsers may cache a permanent redirect, zero value disables the caching
+	PermanentRedirectMaxAge time.Duration
+	// Short URL of a link, the "{domain}" and "{key}" placeholders are replaced by the domain and the key of the link
+	ShortURLTemplate string
+	// Domains serving the short links, the first one is the primary domain
+	ShortDomains []ShortDomain
+	// Serve the preview of a link at its root-level short URL followed by "+"
+	LinkPreview bool
+	// Optional PNG logo which the QR codes may embed in their middle
+	QRLogoFile string
+	// Response to the visitors of the disabled links: not_found, page, 451 or 403
+	DisabledLinkResponse string
 }
 
 func NewBusinessConfig() (*BusinessConfig, error) {
@@ -23,8 +56,144 @@ func NewBusinessConfig() (*BusinessConfig, error) {
 		return nil, fmt.Errorf("LINK_NOT_FOUND_REDIRECT_URL env variable is not defined")
 	}
 
+	var botUserAgentPatterns []string
+	if value, ok := os.LookupEnv("BOT_USER_AGENT_PATTERNS"); ok {
+		botUserAgentPatterns = strings.Split(value, ",")
+	}
+
+	urlMaxLength := 2048
+	if value, ok := os.LookupEnv("URL_MAX_LENGTH"); ok {
+		var err error
+		urlMaxLength, err = strconv.Atoi(value)
+		if err != nil || urlMaxLength <= 0 {
+			return nil, fmt.Errorf("URL_MAX_LENGTH env variable must be a positive integer")
+		}
+	}
+
+	var shortenerDomains []string
+	if value, ok := os.LookupEnv("SHORTENER_DOMAINS"); ok {
+		shortenerDomains = strings.Split(value, ",")
+	}
+
+	redirectStatus := 302
+	if value, ok := os.LookupEnv("REDIRECT_STATUS"); ok {
+		var err error
+		redirectStatus, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("REDIRECT_STATUS env variable is not an integer: %w", err)
+		}
+		switch redirectStatus {
+		case 301, 302, 307, 308:
+		default:
+			return nil, fmt.Errorf("REDIRECT_STATUS env variable must be one of 301, 302, 307 or 308")
+		}
+	}
+
+	permanentRedirectMaxAge := time.Hour
+	if value, ok := os.LookupEnv("PERMANENT_REDIRECT_MAX_AGE"); ok {
+		var err error
+		permanentRedirectMaxAge, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("PERMANENT_REDIRECT_MAX_AGE env variable is not a duration: %w", err)
+		}
+		if permanentRedirectMaxAge < 0 {
+			return nil, fmt.Errorf("PERMANENT_REDIRECT_MAX_AGE env variable must not be negative")
+		}
+	}
+
+	parsedBaseURL, err := url.Parse(baseURL)
+	if err != nil || parsedBaseURL.Host == "" {
+		return nil, fmt.Errorf("BASE_URL env variable must be an absolute URL")
+	}
+
+	shortDomains := []ShortDomain{{
+		Host:                    normalizeHost(parsedBaseURL.Host),
+		LinkNotFoundRedirectURL: linkNotFoundRedirectURL,
+	}}
+	if value, ok := os.LookupEnv("SHORT_DOMAINS"); ok && value != "" {
+		shortDomains, err = parseShortDomains(value, linkNotFoundRedirectURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// with several domains the short URL must name the domain of the link
+	shortURLTemplate := baseURL + "/{key}"
+	if len(shortDomains) > 1 {
+		shortURLTemplate = parsedBaseURL.Scheme + "://{domain}/{key}"
+	}
+	if value, ok := os.LookupEnv("SHORT_URL_TEMPLATE"); ok && value != "" {
+		shortURLTemplate = value
+	}
+	if !strings.Contains(shortURLTemplate, "{key}") {
+		return nil, fmt.Errorf("SHORT_URL_TEMPLATE env variable must contain the {key} placeholder")
+	}
+	if len(shortDomains) > 1 && !strings.Contains(shortURLTemplate, "{domain}") {
+		return nil, fmt.Errorf("SHORT_URL_TEMPLATE env variable must contain the {domain} placeholder when SHORT_DOMAINS has several domains")
+	}
+
+	linkPreview := true
+	if value, ok := os.LookupEnv("LINK_PREVIEW"); ok {
+		var err error
+		linkPreview, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("LINK_PREVIEW env variable is not a boolean: %w", err)
+		}
+	}
+
+	disabledLinkResponse := "not_found"
+	if value, ok := os.LookupEnv("DISABLED_LINK_RESPONSE"); ok && value != "" {
+		switch value {
+		case "not_found", "page", "451", "403":
+			disabledLinkResponse = value
+		default:
+			return nil, fmt.Errorf("DISABLED_LINK_RESPONSE env variable must be one of not_found, page, 451 or 403")
+		}
+	}
+
 	return &BusinessConfig{
 		BaseURL:                 baseURL,
 		LinkNotFoundRedirectURL: linkNotFoundRedirectURL,
+		BotUserAgentPatterns:    botUserAgentPatterns,
+		URLMaxLength:            urlMaxLength,
+		URLRulesFile:            os.Getenv("URL_RULES_FILE"),
+		ShortenerDomains:        shortenerDomains,
+		RedirectStatus:          redirectStatus,
+		PermanentRedirectMaxAge: permanentRedirectMaxAge,
+		ShortURLTemplate:        shortURLTemplate,
+		ShortDomains:            shortDomains,
+		LinkPreview:             linkPreview,
+		QRLogoFile:              os.Getenv("QR_LOGO_FILE"),
+		DisabledLinkResponse:    disabledLinkResponse,
 	}, nil
 }
+
+// parseShortDomains reads a comma separated list of hosts, each optionally followed by "=" and the URL
+// its unknown links are redirected to. The domains without their own URL use the default one.
+func parseShortDomains(value string, defaultNotFoundURL string) ([]ShortDomain, error) {
+	var domains []ShortDomain
+	seen := make(map[string]struct{})
+	for _, entry := range strings.Split(value, ",") {
+		host, notFoundURL, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		host = normalizeHost(host)
+		if host == "" || strings.ContainsAny(host, "/ ") {
+			return nil, fmt.Errorf("SHORT_DOMAINS env variable has an invalid domain %q", host)
+		}
+		if _, ok := seen[host]; ok {
+			return nil, fmt.Errorf("SHORT_DOMAINS env variable has the domain %q more than once", host)
+		}
+		seen[host] = struct{}{}
+
+		if notFoundURL == "" {
+			notFoundURL = defaultNotFoundURL
+		}
+		domains = append(domains, ShortDomain{Host: host, LinkNotFoundRedirectURL: notFoundURL})
+	}
+
+	return domains, nil
+}
+
+// normalizeHost lowercases the host and removes the trailing dot of a fully qualified name.
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
@@ -3,11 +3,15 @@ package config
 import "fmt"
 
 type Config struct {
-	Business *BusinessConfig
-	Http     *HttpConfig
-	Grpc     *GrpcConfig
-	MongoDB  *MongoDBConfig
-	Redis    *RedisConfig
+	Business  *BusinessConfig
+	Http      *HttpConfig
+	Grpc      *GrpcConfig
+	MongoDB   *MongoDBConfig
+	Redis     *RedisConfig
+	Hits      *HitsConfig
+	Trash     *TrashConfig
+	JWT       *JWTConfig
+	RateLimit *RateLimitConfig
 }
 
 func New() (*Config, error) {
@@ -36,11 +40,35 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("init redis config: %w", err)
 	}
 
+	hitsConfig, err := NewHitsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("init hits config: %w", err)
+	}
+
+	trashConfig, err := NewTrashConfig()
+	if err != nil {
+		return nil, fmt.Errorf("init trash config: %w", err)
+	}
+
+	jwtConfig, err := NewJWTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("init jwt config: %w", err)
+	}
+
+	rateLimitConfig, err := NewRateLimitConfig()
+	if err != nil {
+		return nil, fmt.Errorf("init rate limit config: %w", err)
+	}
+
 	return &Config{
-		Business: businessConfig,
-		Http:     httpConfig,
-		Grpc:     grpcConfig,
-		MongoDB:  mongodbConfig,
-		Redis:    redisConfig,
+		Business:  businessConfig,
+		Http:      httpConfig,
+		Grpc:      grpcConfig,
+		MongoDB:   mongodbConfig,
+		Redis:     redisConfig,
+		Hits:      hitsConfig,
+		Trash:     trashConfig,
+		JWT:       jwtConfig,
+		RateLimit: rateLimitConfig,
 	}, nil
 }
@@ -1,13 +1,17 @@
 package config
 
 import (
+	"fmt"
 	"net"
 	"os"
+	"strconv"
 )
 
 type GrpcConfig struct {
 	Host string
 	Port string
+	// Trust the client IP metadata set by a reverse proxy (x-forwarded-for, etc.)
+	TrustProxy bool
 }
 
 func NewGrpcConfig() (*GrpcConfig, error) {
@@ -18,9 +22,19 @@ func NewGrpcConfig() (*GrpcConfig, error) {
 		port = "50051"
 	}
 
+	trustProxy := false
+	if value, ok := os.LookupEnv("GRPC_TRUST_PROXY"); ok {
+		var err error
+		trustProxy, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("GRPC_TRUST_PROXY env variable is not a boolean: %w", err)
+		}
+	}
+
 	return &GrpcConfig{
-		Host: host,
-		Port: port,
+		Host:       host,
+		Port:       port,
+		TrustProxy: trustProxy,
 	}, nil
 }
 
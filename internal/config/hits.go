@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+type HitsConfig struct {
+	// Count hits in Redis and write them to MongoDB in batches
+	WriteBehind bool
+	// How often the buffered hits are written to MongoDB
+	FlushInterval time.Duration
+}
+
+func NewHitsConfig() (*HitsConfig, error) {
+	writeBehind := false
+	if value, ok := os.LookupEnv("HITS_WRITE_BEHIND"); ok {
+		var err error
+		writeBehind, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("HITS_WRITE_BEHIND env variable is not a boolean: %w", err)
+		}
+	}
+
+	flushInterval := 5 * time.Second
+	if value, ok := os.LookupEnv("HITS_FLUSH_INTERVAL"); ok {
+		var err error
+		flushInterval, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("HITS_FLUSH_INTERVAL env variable is not a duration: %w", err)
+		}
+		if flushInterval <= 0 {
+			return nil, fmt.Errorf("HITS_FLUSH_INTERVAL env variable must be positive")
+		}
+	}
+
+	return &HitsConfig{
+		WriteBehind:   writeBehind,
+		FlushInterval: flushInterval,
+	}, nil
+}
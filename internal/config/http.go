@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 )
 
 type HttpConfig struct {
-	Host      string
-	Port      string
+	Host string
+	Port string
+	// Optional shared secret accepted as an admin API key, kept for older deployments
 	APISecret string
+	// Trust the client IP headers set by a reverse proxy (X-Forwarded-For, etc.)
+	TrustProxy bool
 }
 
 func NewHTTPConfig() (*HttpConfig, error) {
@@ -20,15 +24,22 @@ func NewHTTPConfig() (*HttpConfig, error) {
 		port = "3000"
 	}
 
-	apiSecret, ok := os.LookupEnv("API_SECRET")
-	if !ok {
-		return nil, fmt.Errorf("API_SECRET env variable is not defined")
+	apiSecret := os.Getenv("API_SECRET")
+
+	trustProxy := false
+	if value, ok := os.LookupEnv("TRUST_PROXY"); ok {
+		var err error
+		trustProxy, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("TRUST_PROXY env variable is not a boolean: %w", err)
+		}
 	}
 
 	return &HttpConfig{
-		Host:      host,
-		Port:      port,
-		APISecret: apiSecret,
+		Host:       host,
+		Port:       port,
+		APISecret:  apiSecret,
+		TrustProxy: trustProxy,
 	}, nil
 }
 
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+type JWTConfig struct {
+	// Path of a JWKS file with the keys which sign the tokens, takes precedence over JWKSURL
+	JWKSFile string
+	// URL of the JWKS of the identity provider
+	JWKSURL string
+	// Expected "iss" claim
+	Issuer string
+	// Expected "aud" claim
+	Audience string
+	// Claim holding the granted scopes, either a space separated string or an array
+	ScopeClaim string
+	// Claim holding the identity of the caller
+	OwnerClaim string
+}
+
+// Enabled reports whether the bearer tokens may be JWTs.
+func (c *JWTConfig) Enabled() bool {
+	return c.JWKSFile != "" || c.JWKSURL != ""
+}
+
+func NewJWTConfig() (*JWTConfig, error) {
+	config := &JWTConfig{
+		JWKSFile:   os.Getenv("JWT_JWKS_FILE"),
+		JWKSURL:    os.Getenv("JWT_JWKS_URL"),
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+		ScopeClaim: "scope",
+		OwnerClaim: "sub",
+	}
+
+	if value, ok := os.LookupEnv("JWT_SCOPE_CLAIM"); ok && value != "" {
+		config.ScopeClaim = value
+	}
+	if value, ok := os.LookupEnv("JWT_OWNER_CLAIM"); ok && value != "" {
+		config.OwnerClaim = value
+	}
+
+	if !config.Enabled() {
+		return config, nil
+	}
+
+	// a token of any other application signed by the same provider must not be accepted
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("JWT_ISSUER env variable is required when JWT authentication is enabled")
+	}
+	if config.Audience == "" {
+		return nil, fmt.Errorf("JWT_AUDIENCE env variable is required when JWT authentication is enabled")
+	}
+
+	return config, nil
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitRule allows up to Requests requests per client within any Window, zero disables the limit.
+type RateLimitRule struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimitConfig has a rule per limited route. The routes without their own variable keep the budget
+// they shared before: bulk creations the one of the creations, previews and expansions the one of the redirects.
+type RateLimitConfig struct {
+	// Link creations per API key
+	Create RateLimitRule
+	// Bulk creations per API key, a batch counts as one request
+	Bulk RateLimitRule
+	// Redirects per client IP
+	Redirect RateLimitRule
+	// Link previews per client IP
+	Preview RateLimitRule
+	// Expansions per client IP
+	Expand RateLimitRule
+	// Stats and click event queries per API key
+	Stats RateLimitRule
+	// QR code renderings per API key
+	QR RateLimitRule
+}
+
+func NewRateLimitConfig() (*RateLimitConfig, error) {
+	create, err := parseRateLimitRule("RATE_LIMIT_CREATE", RateLimitRule{})
+	if err != nil {
+		return nil, err
+	}
+
+	bulk, err := parseRateLimitRule("RATE_LIMIT_BULK", create)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect, err := parseRateLimitRule("RATE_LIMIT_REDIRECT", RateLimitRule{})
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := parseRateLimitRule("RATE_LIMIT_PREVIEW", redirect)
+	if err != nil {
+		return nil, err
+	}
+
+	expand, err := parseRateLimitRule("RATE_LIMIT_EXPAND", redirect)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := parseRateLimitRule("RATE_LIMIT_STATS", RateLimitRule{})
+	if err != nil {
+		return nil, err
+	}
+
+	qr, err := parseRateLimitRule("RATE_LIMIT_QR", RateLimitRule{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateLimitConfig{
+		Create:   create,
+		Bulk:     bulk,
+		Redirect: redirect,
+		Preview:  preview,
+		Expand:   expand,
+		Stats:    stats,
+		QR:       qr,
+	}, nil
+}
+
+// parseRateLimitRule reads a limit written as "<requests>/<window>", for example "100/1m".
+// An undefined variable is the default rule, an empty one disables the limit.
+func parseRateLimitRule(name string, defaultRule RateLimitRule) (RateLimitRule, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultRule, nil
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return RateLimitRule{}, nil
+	}
+
+	requestsValue, windowValue, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimitRule{}, fmt.Errorf("%s env variable must be formatted as <requests>/<window>", name)
+	}
+
+	requests, err := strconv.Atoi(strings.TrimSpace(requestsValue))
+	if err != nil || requests < 0 {
+		return RateLimitRule{}, fmt.Errorf("%s env variable has an invalid number of requests", name)
+	}
+
+	window, err := time.ParseDuration(strings.TrimSpace(windowValue))
+	if err != nil || window <= 0 {
+		return RateLimitRule{}, fmt.Errorf("%s env variable has an invalid window", name)
+	}
+
+	return RateLimitRule{
+		Requests: requests,
+		Window:   window,
+	}, nil
+}
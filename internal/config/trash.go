@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+type TrashConfig struct {
+	// How long the deleted links stay restorable before they are purged, zero value keeps them forever
+	PurgeAfter time.Duration
+	// How often the links past the purge period are removed
+	PurgeInterval time.Duration
+}
+
+func NewTrashConfig() (*TrashConfig, error) {
+	purgeAfter := 30 * 24 * time.Hour
+	if value, ok := os.LookupEnv("TRASH_PURGE_AFTER"); ok {
+		var err error
+		purgeAfter, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("TRASH_PURGE_AFTER env variable is not a duration: %w", err)
+		}
+		if purgeAfter < 0 {
+			return nil, fmt.Errorf("TRASH_PURGE_AFTER env variable must not be negative")
+		}
+	}
+
+	purgeInterval := time.Hour
+	if value, ok := os.LookupEnv("TRASH_PURGE_INTERVAL"); ok {
+		var err error
+		purgeInterval, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("TRASH_PURGE_INTERVAL env variable is not a duration: %w", err)
+		}
+		if purgeInterval <= 0 {
+			return nil, fmt.Errorf("TRASH_PURGE_INTERVAL env variable must be positive")
+		}
+	}
+
+	return &TrashConfig{
+		PurgeAfter:    purgeAfter,
+		PurgeInterval: purgeInterval,
+	}, nil
+}
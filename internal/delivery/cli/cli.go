@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +16,7 @@ func Run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer,
 	rootCmd.SetOut(stdout)
 	rootCmd.SetErr(stderr)
 	rootCmd.SetArgs(args)
-	rootCmd.SetContext(ctx)
+	rootCmd.SetContext(domain.ContextWithTransport(ctx, domain.TransportCLI))
 
 	return rootCmd.Execute()
 }
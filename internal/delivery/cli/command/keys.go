@@ -0,0 +1,150 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+type keysCommand struct {
+	apiKeyUsecase domain.APIKeyUsecase
+}
+
+func NewKeysCommand(apiKeyUsecase domain.APIKeyUsecase) *cobra.Command {
+	h := &keysCommand{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage API keys",
+	}
+
+	cmd.AddCommand(
+		h.newCreateCommand(),
+		h.newListCommand(),
+		h.newRevokeCommand(),
+	)
+
+	return cmd
+}
+
+func (h *keysCommand) newCreateCommand() *cobra.Command {
+	var name string
+	var scopes []string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create API key",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			createAction := domain.CreateAPIKeyAction{Name: name}
+			for _, scope := range scopes {
+				createAction.Scopes = append(createAction.Scopes, domain.Scope(scope))
+			}
+
+			out, err := h.apiKeyUsecase.Create(cmd.Context(), createAction)
+			if err != nil {
+				return mapAPIKeyError(err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(),
+				"ID: %s\nScopes: %s\nToken: %s\n\nThe token is shown only once, store it now.\n",
+				out.Key.ID, joinScopes(out.Key.Scopes), out.Token)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name describing the key owner")
+	cmd.Flags().StringSliceVar(&scopes, "scope", nil, "Granted scope, repeatable: "+joinScopes(domain.AllScopes))
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("scope")
+
+	return cmd
+}
+
+func (h *keysCommand) newListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API keys",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			keys, err := h.apiKeyUsecase.List(cmd.Context())
+			if err != nil {
+				return mapAPIKeyError(err)
+			}
+
+			return writeKeysTable(cmd.OutOrStdout(), keys)
+		},
+	}
+
+	return cmd
+}
+
+func (h *keysCommand) newRevokeCommand() *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke API key",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := h.apiKeyUsecase.Revoke(cmd.Context(), id); err != nil {
+				return mapAPIKeyError(err)
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Revoked key: %s\n", id)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "API key ID")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func writeKeysTable(w io.Writer, keys []domain.APIKey) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tCREATED AT\tLAST USED AT\tREVOKED AT")
+	for _, key := range keys {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Name, key.Prefix, joinScopes(key.Scopes),
+			key.CreatedAt.Format(time.RFC3339), formatOptionalTime(key.LastUsedAt), formatOptionalTime(key.RevokedAt))
+	}
+
+	return tw.Flush()
+}
+
+func joinScopes(scopes []domain.Scope) string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+
+	return strings.Join(values, ",")
+}
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func mapAPIKeyError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		return errors.New("API key not found")
+	case errors.Is(err, domain.ErrInvalidAPIKeyName):
+		return errors.New("invalid name, expected 1 to 64 characters")
+	case errors.Is(err, domain.ErrInvalidAPIKeyScope):
+		return fmt.Errorf("invalid scope, expected one or more of: %s", joinScopes(domain.AllScopes))
+	default:
+		return fmt.Errorf("internal error: %w", err)
+	}
+}
@@ -1,8 +1,15 @@
 package command
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/OsoianMarcel/url-shortener/internal/domain"
 	"github.com/spf13/cobra"
@@ -25,7 +32,17 @@ func NewShortCommand(shortLinkUsecase domain.ShortLinkUsecase) *cobra.Command {
 	cmd.AddCommand(
 		h.newCreateCommand(),
 		h.newExpandCommand(),
+		h.newUpdateCommand(),
 		h.newDeleteCommand(),
+		h.newRestoreCommand(),
+		h.newPurgeCommand(),
+		h.newDisableCommand(),
+		h.newEnableCommand(),
+		h.newHistoryCommand(),
+		h.newListCommand(),
+		h.newQRCodeCommand(),
+		h.newImportCommand(),
+		h.newExportCommand(),
 	)
 
 	return cmd
@@ -33,22 +50,63 @@ func NewShortCommand(shortLinkUsecase domain.ShortLinkUsecase) *cobra.Command {
 
 func (h *shortCommand) newCreateCommand() *cobra.Command {
 	var originalURL string
+	var linkDomain string
+	var alias string
+	var expiresAt string
+	var ttl time.Duration
+	var maxHits uint
+	var password string
+	var redirectStatus int
+	var queryForwarding string
+	var forwardPath bool
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create short URL",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			out, err := h.shortLinkUsecase.Create(cmd.Context(), domain.CreateAction{OriginalURL: originalURL})
+			createAction := domain.CreateAction{
+				OriginalURL:     originalURL,
+				Domain:          linkDomain,
+				Alias:           alias,
+				TTL:             ttl,
+				MaxHits:         maxHits,
+				Password:        password,
+				RedirectStatus:  redirectStatus,
+				QueryForwarding: domain.QueryForwarding(queryForwarding),
+				ForwardPath:     forwardPath,
+			}
+			if expiresAt != "" {
+				t, err := parseExpiresAt(expiresAt)
+				if err != nil {
+					return err
+				}
+				createAction.ExpiresAt = t
+			}
+
+			out, err := h.shortLinkUsecase.Create(cmd.Context(), createAction)
 			if err != nil {
 				return mapShortLinkError(err)
 			}
 
 			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Key: %s\nShort URL: %s\n", out.Key, out.ShortURL)
+			if err == nil && !out.ExpiresAt.IsZero() {
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Expires at: %s\n", out.ExpiresAt.Format(time.RFC3339))
+			}
 			return err
 		},
 	}
 
 	cmd.Flags().StringVar(&originalURL, "url", "", "Original URL to shorten")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain, the primary domain when empty (optional)")
+	cmd.Flags().StringVar(&alias, "alias", "", "Custom key (optional)")
+	cmd.Flags().StringVar(&expiresAt, "expires-at", "", "Expiry time in RFC 3339 format (optional)")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Expiry relative to now, e.g. 72h (optional)")
+	cmd.Flags().UintVar(&maxHits, "max-hits", 0, "Maximum number of redirects, 0 means unlimited (optional)")
+	cmd.Flags().StringVar(&password, "password", "", "Password required to follow the link (optional)")
+	cmd.Flags().IntVar(&redirectStatus, "redirect-status", 0, "Redirect status: 301, 302, 307 or 308, 0 means the server default (optional)")
+	cmd.Flags().StringVar(&queryForwarding, "query-forwarding", "", "Forward the query on conflict: keep, override or append (optional)")
+	cmd.Flags().BoolVar(&forwardPath, "forward-path", false, "Forward the path following the key (optional)")
+	cmd.MarkFlagsMutuallyExclusive("expires-at", "ttl")
 	_ = cmd.MarkFlagRequired("url")
 
 	return cmd
@@ -56,12 +114,14 @@ func (h *shortCommand) newCreateCommand() *cobra.Command {
 
 func (h *shortCommand) newExpandCommand() *cobra.Command {
 	var key string
+	var linkDomain string
+	var password string
 
 	cmd := &cobra.Command{
 		Use:   "expand",
 		Short: "Expand key to original URL",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ent, err := h.shortLinkUsecase.Expand(cmd.Context(), key)
+			ent, err := h.shortLinkUsecase.Expand(cmd.Context(), domain.LinkRef{Domain: linkDomain, Key: key}, domain.PasswordAttempt{Password: password})
 			if err != nil {
 				return mapShortLinkError(err)
 			}
@@ -72,6 +132,88 @@ func (h *shortCommand) newExpandCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	cmd.Flags().StringVar(&password, "password", "", "Password of a protected link")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
+
+func (h *shortCommand) newUpdateCommand() *cobra.Command {
+	var key string
+	var linkDomain string
+	var originalURL string
+	var expiresAt string
+	var ttl time.Duration
+	var noExpiry bool
+	var maxHits uint
+	var password string
+	var redirectStatus int
+	var queryForwarding string
+	var forwardPath bool
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update short URL by key, only the provided flags are changed",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			flags := cmd.Flags()
+			updateAction := domain.UpdateAction{Key: key, Domain: linkDomain}
+
+			if flags.Changed("url") {
+				updateAction.OriginalURL = &originalURL
+			}
+			if flags.Changed("expires-at") {
+				t, err := parseExpiresAt(expiresAt)
+				if err != nil {
+					return err
+				}
+				updateAction.ExpiresAt = &t
+			}
+			if flags.Changed("ttl") {
+				updateAction.TTL = &ttl
+			}
+			if noExpiry {
+				updateAction.ExpiresAt = &time.Time{}
+			}
+			if flags.Changed("max-hits") {
+				updateAction.MaxHits = &maxHits
+			}
+			if flags.Changed("password") {
+				updateAction.Password = &password
+			}
+			if flags.Changed("redirect-status") {
+				updateAction.RedirectStatus = &redirectStatus
+			}
+			if flags.Changed("query-forwarding") {
+				policy := domain.QueryForwarding(queryForwarding)
+				updateAction.QueryForwarding = &policy
+			}
+			if flags.Changed("forward-path") {
+				updateAction.ForwardPath = &forwardPath
+			}
+
+			ent, err := h.shortLinkUsecase.Update(cmd.Context(), updateAction)
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Updated key: %s\nOriginal URL: %s\n", ent.Key, ent.OriginalURL)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	cmd.Flags().StringVar(&originalURL, "url", "", "New original URL")
+	cmd.Flags().StringVar(&expiresAt, "expires-at", "", "New expiry time in RFC 3339 format")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "New expiry relative to now, e.g. 72h")
+	cmd.Flags().BoolVar(&noExpiry, "no-expiry", false, "Remove the expiry")
+	cmd.Flags().UintVar(&maxHits, "max-hits", 0, "New maximum number of redirects, 0 removes the limit")
+	cmd.Flags().StringVar(&password, "password", "", "New password, an empty value removes the password")
+	cmd.Flags().IntVar(&redirectStatus, "redirect-status", 0, "New redirect status: 301, 302, 307 or 308, 0 resets to the server default")
+	cmd.Flags().StringVar(&queryForwarding, "query-forwarding", "", "New query forwarding: keep, override or append, an empty value disables it")
+	cmd.Flags().BoolVar(&forwardPath, "forward-path", false, "Forward the path following the key")
+	cmd.MarkFlagsMutuallyExclusive("expires-at", "ttl", "no-expiry")
 	_ = cmd.MarkFlagRequired("key")
 
 	return cmd
@@ -79,32 +221,320 @@ func (h *shortCommand) newExpandCommand() *cobra.Command {
 
 func (h *shortCommand) newDeleteCommand() *cobra.Command {
 	var key string
+	var linkDomain string
 
 	cmd := &cobra.Command{
 		Use:   "delete",
-		Short: "Delete short URL by key",
+		Short: "Move short URL to the trash by key",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			if err := h.shortLinkUsecase.Delete(cmd.Context(), key); err != nil {
+			if err := h.shortLinkUsecase.Delete(cmd.Context(), domain.LinkRef{Domain: linkDomain, Key: key}); err != nil {
 				return mapShortLinkError(err)
 			}
 
-			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Deleted key: %s\n", key)
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Moved key to the trash: %s\n", key)
 			return err
 		},
 	}
 
 	cmd.Flags().StringVar(&key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
+
+func (h *shortCommand) newQRCodeCommand() *cobra.Command {
+	var query domain.QRCodeQuery
+	var format string
+	var margin int
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "qr",
+		Short: "Save the QR code of a short URL",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			query.Format = domain.QRCodeFormat(format)
+			if format == "" && strings.EqualFold(filepath.Ext(out), ".svg") {
+				query.Format = domain.QRCodeFormatSVG
+			}
+			if cmd.Flags().Changed("margin") {
+				query.Margin = &margin
+			}
+
+			qrCode, err := h.shortLinkUsecase.QRCode(cmd.Context(), query)
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+
+			if err := os.WriteFile(out, qrCode.Data, 0o644); err != nil {
+				return fmt.Errorf("write qr code: %w", err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Saved QR code: %s\n", out)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&query.Key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&query.Domain, "domain", "", "Short domain of the link, the primary domain when empty")
+	cmd.Flags().StringVar(&out, "out", "", "Output file")
+	cmd.Flags().StringVar(&format, "format", "", "Image format: png or svg, inferred from the output file extension when empty")
+	cmd.Flags().IntVar(&query.Size, "size", 0, "Width and height in pixels, 256 when zero")
+	cmd.Flags().IntVar(&margin, "margin", 4, "Quiet zone around the code, in modules")
+	cmd.Flags().StringVar(&query.Level, "level", "", "Error correction level: L, M, Q or H (optional)")
+	cmd.Flags().StringVar(&query.Foreground, "fg", "", "Foreground hex color, e.g. #000000 (optional)")
+	cmd.Flags().StringVar(&query.Background, "bg", "", "Background hex color, e.g. #ffffff (optional)")
+	cmd.Flags().BoolVar(&query.Logo, "logo", false, "Embed the logo of QR_LOGO_FILE (optional)")
 	_ = cmd.MarkFlagRequired("key")
+	_ = cmd.MarkFlagRequired("out")
 
 	return cmd
 }
 
+// shortLinkOutput is the JSON representation of a link in the CLI output.
+type shortLinkOutput struct {
+	// Omitted for the primary domain
+	Domain    string     `json:"domain,omitempty"`
+	Key       string     `json:"key"`
+	URL       string     `json:"url"`
+	Hits      uint       `json:"hits"`
+	MaxHits   uint       `json:"max_hits,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// The password itself is never printed
+	PasswordProtected bool `json:"password_protected"`
+	// Omitted when the link uses the server default
+	RedirectStatus  int    `json:"redirect_status,omitempty"`
+	QueryForwarding string `json:"query_forwarding,omitempty"`
+	ForwardPath     bool   `json:"forward_path"`
+	Disabled        bool   `json:"disabled"`
+	// Set only for the links in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
+}
+
+type listOutput struct {
+	Items      []shortLinkOutput `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+func (h *shortCommand) newListCommand() *cobra.Command {
+	var query domain.ListQuery
+	var createdAfter, createdBefore string
+	var sortBy string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List short URLs",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var err error
+			if createdAfter != "" {
+				if query.CreatedAfter, err = time.Parse(time.RFC3339, createdAfter); err != nil {
+					return errors.New("invalid --created-after, expected RFC 3339 time")
+				}
+			}
+			if createdBefore != "" {
+				if query.CreatedBefore, err = time.Parse(time.RFC3339, createdBefore); err != nil {
+					return errors.New("invalid --created-before, expected RFC 3339 time")
+				}
+			}
+
+			switch sortBy {
+			case "created_at":
+				query.SortBy = domain.ListSortByCreatedAt
+			case "hits":
+				query.SortBy = domain.ListSortByHits
+			default:
+				return errors.New("invalid --sort, expected created_at or hits")
+			}
+
+			if output != "table" && output != "json" {
+				return errors.New("invalid --output, expected table or json")
+			}
+
+			result, err := h.shortLinkUsecase.List(cmd.Context(), query)
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+
+			if output == "json" {
+				return writeListJSON(cmd.OutOrStdout(), result)
+			}
+
+			if query.Deleted {
+				return writeTrashTable(cmd.OutOrStdout(), result)
+			}
+
+			return writeListTable(cmd.OutOrStdout(), result)
+		},
+	}
+
+	cmd.Flags().StringVar(&query.Host, "host", "", "Filter by destination host")
+	cmd.Flags().StringVar(&query.KeyPrefix, "prefix", "", "Filter by key prefix")
+	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Filter by creation time, inclusive, RFC 3339")
+	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Filter by creation time, exclusive, RFC 3339")
+	cmd.Flags().StringVar(&sortBy, "sort", "created_at", "Sort field: created_at or hits")
+	cmd.Flags().BoolVar(&query.Ascending, "asc", false, "Sort in ascending order")
+	cmd.Flags().IntVar(&query.Limit, "limit", 20, "Page size")
+	cmd.Flags().StringVar(&query.Cursor, "cursor", "", "Cursor of the next page")
+	cmd.Flags().BoolVar(&query.Deleted, "deleted", false, "List the links of the trash")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func writeListJSON(w io.Writer, result domain.ListResult) error {
+	out := listOutput{
+		Items:      make([]shortLinkOutput, 0, len(result.Links)),
+		NextCursor: result.NextCursor,
+	}
+	for _, ent := range result.Links {
+		item := shortLinkOutput{
+			Domain:            ent.Domain,
+			Key:               ent.Key,
+			URL:               ent.OriginalURL,
+			Hits:              ent.Hits,
+			MaxHits:           ent.MaxHits,
+			CreatedAt:         ent.CreatedAt,
+			PasswordProtected: ent.IsProtected(),
+			RedirectStatus:    ent.RedirectStatus,
+			QueryForwarding:   string(ent.QueryForwarding),
+			ForwardPath:       ent.ForwardPath,
+			Disabled:          ent.Disabled,
+			DeletedBy:         ent.DeletedBy,
+		}
+		if !ent.ExpiresAt.IsZero() {
+			item.ExpiresAt = &ent.ExpiresAt
+		}
+		if ent.IsDeleted() {
+			item.DeletedAt = &ent.DeletedAt
+		}
+		out.Items = append(out.Items, item)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(out)
+}
+
+func writeListTable(w io.Writer, result domain.ListResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tHITS\tCREATED AT\tEXPIRES AT\tURL")
+	for _, ent := range result.Links {
+		expiresAt := "-"
+		if !ent.ExpiresAt.IsZero() {
+			expiresAt = ent.ExpiresAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n",
+			ent.Ref(), ent.Hits, ent.CreatedAt.Format(time.RFC3339), expiresAt, ent.OriginalURL)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if result.NextCursor != "" {
+		_, err := fmt.Fprintf(w, "\nNext cursor: %s\n", result.NextCursor)
+		return err
+	}
+
+	return nil
+}
+
+// writeTrashTable prints the deleted links with the time and the owner of their deletion.
+func writeTrashTable(w io.Writer, result domain.ListResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tDELETED AT\tDELETED BY\tURL")
+	for _, ent := range result.Links {
+		deletedBy := ent.DeletedBy
+		if deletedBy == "" {
+			deletedBy = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			ent.Ref(), ent.DeletedAt.Format(time.RFC3339), deletedBy, ent.OriginalURL)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if result.NextCursor != "" {
+		_, err := fmt.Fprintf(w, "\nNext cursor: %s\n", result.NextCursor)
+		return err
+	}
+
+	return nil
+}
+
+func parseExpiresAt(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid expiration, expected RFC 3339 time")
+	}
+
+	return t, nil
+}
+
 func mapShortLinkError(err error) error {
 	switch {
 	case errors.Is(err, domain.ErrInvalidURL):
 		return errors.New("invalid URL")
+	case errors.Is(err, domain.ErrURLTooLong):
+		return errors.New("the URL is too long")
+	case errors.Is(err, domain.ErrDomainNotAllowed):
+		return errors.New("the URL domain is not allowed")
+	case errors.Is(err, domain.ErrPrivateAddress):
+		return errors.New("the URL points to a private network address")
+	case errors.Is(err, domain.ErrShortenerURL):
+		return errors.New("the URL points to a URL shortener")
 	case errors.Is(err, domain.ErrShortLinkNotFound):
 		return errors.New("link not found")
+	case errors.Is(err, domain.ErrInvalidAlias):
+		return errors.New("invalid alias")
+	case errors.Is(err, domain.ErrReservedAlias):
+		return errors.New("the alias is reserved")
+	case errors.Is(err, domain.ErrShortLinkKeyExists):
+		return errors.New("the alias is already taken")
+	case errors.Is(err, domain.ErrInvalidExpiration):
+		return errors.New("invalid expiration")
+	case errors.Is(err, domain.ErrShortLinkExpired):
+		return errors.New("link expired")
+	case errors.Is(err, domain.ErrShortLinkExhausted):
+		return errors.New("link hit limit reached")
+	case errors.Is(err, domain.ErrShortLinkDisabled):
+		return errors.New("link disabled")
+	case errors.Is(err, domain.ErrInvalidListQuery):
+		return errors.New("invalid list query")
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return errors.New("invalid cursor")
+	case errors.Is(err, domain.ErrInvalidStatsQuery):
+		return errors.New("invalid stats query")
+	case errors.Is(err, domain.ErrInvalidLinkPassword):
+		return errors.New("invalid password")
+	case errors.Is(err, domain.ErrPasswordRequired):
+		return errors.New("the link is password protected")
+	case errors.Is(err, domain.ErrWrongPassword):
+		return errors.New("wrong password")
+	case errors.Is(err, domain.ErrTooManyPasswordAttempts):
+		return errors.New("too many password attempts")
+	case errors.Is(err, domain.ErrInvalidRedirectStatus):
+		return errors.New("invalid redirect status, expected 301, 302, 307 or 308")
+	case errors.Is(err, domain.ErrInvalidQueryForwarding):
+		return errors.New("invalid query forwarding, expected keep, override or append")
+	case errors.Is(err, domain.ErrUnknownDomain):
+		return errors.New("unknown domain, expected one of the SHORT_DOMAINS")
+	case errors.Is(err, domain.ErrInvalidQRCodeQuery):
+		return errors.New("invalid QR code options")
+	case errors.Is(err, domain.ErrInvalidBatchSize):
+		return errors.New("invalid batch size")
+	case errors.Is(err, domain.ErrInvalidExportQuery):
+		return errors.New("invalid export query, --created-after must be before --created-before")
+	case errors.Is(err, domain.ErrInvalidPurgeAge):
+		return errors.New("invalid --older-than, expected a positive duration")
+	case errors.Is(err, domain.ErrQRCodeLogoNotConfigured):
+		return errors.New("no QR code logo is configured, set QR_LOGO_FILE")
 	default:
 		return fmt.Errorf("internal error: %w", err)
 	}
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func (h *shortCommand) newDisableCommand() *cobra.Command {
+	return newSetDisabledCommand("disable", "Pause a short URL without deleting it", "Disabled key", h.shortLinkUsecase.Disable)
+}
+
+func (h *shortCommand) newEnableCommand() *cobra.Command {
+	return newSetDisabledCommand("enable", "Resume a disabled short URL", "Enabled key", h.shortLinkUsecase.Enable)
+}
+
+// newSetDisabledCommand builds the command disabling or enabling a link with the change.
+func newSetDisabledCommand(
+	use string,
+	short string,
+	done string,
+	change func(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error),
+) *cobra.Command {
+	var key string
+	var linkDomain string
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ent, err := change(cmd.Context(), domain.LinkRef{Domain: linkDomain, Key: key})
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", done, ent.Ref())
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
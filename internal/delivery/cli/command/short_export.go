@@ -0,0 +1,77 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/delivery/export"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func (h *shortCommand) newExportCommand() *cobra.Command {
+	var query domain.ExportQuery
+	var createdAfter, createdBefore string
+	var format string
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export every short URL as CSV or JSONL",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			exportFormat, err := export.ParseFormat(format)
+			if err != nil {
+				return errors.New("unknown format, expected csv or jsonl")
+			}
+			if createdAfter != "" {
+				if query.CreatedAfter, err = time.Parse(time.RFC3339, createdAfter); err != nil {
+					return errors.New("invalid --created-after, expected RFC 3339 time")
+				}
+			}
+			if createdBefore != "" {
+				if query.CreatedBefore, err = time.Parse(time.RFC3339, createdBefore); err != nil {
+					return errors.New("invalid --created-before, expected RFC 3339 time")
+				}
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if out != "" && out != "-" {
+				f, err := os.Create(out)
+				if err != nil {
+					return fmt.Errorf("create file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			writer := export.NewWriter(w, exportFormat)
+			exported := 0
+			err = h.shortLinkUsecase.Export(cmd.Context(), query, func(link domain.ShortLink) error {
+				exported++
+				return writer.Write(link)
+			})
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("write export: %w", err)
+			}
+
+			if out != "" && out != "-" {
+				_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d links to %s\n", exported, out)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "csv", "Output format: csv or jsonl")
+	cmd.Flags().StringVar(&out, "out", "", "Output file, the standard output when empty")
+	cmd.Flags().StringVar(&query.Domain, "domain", "", "Export only the links of the short domain (optional)")
+	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Export only the links created at or after the RFC 3339 time (optional)")
+	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Export only the links created before the RFC 3339 time (optional)")
+
+	return cmd
+}
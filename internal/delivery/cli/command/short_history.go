@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func (h *shortCommand) newHistoryCommand() *cobra.Command {
+	var query domain.HistoryQuery
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the changes of a short URL, newest first",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			result, err := h.shortLinkUsecase.History(cmd.Context(), query)
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+
+			return writeHistoryTable(cmd.OutOrStdout(), result)
+		},
+	}
+
+	cmd.Flags().StringVar(&query.LinkKey, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&query.LinkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	cmd.Flags().IntVar(&query.Limit, "limit", 20, "Page size")
+	cmd.Flags().StringVar(&query.Cursor, "cursor", "", "Cursor of the next page")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
+
+func writeHistoryTable(w io.Writer, result domain.HistoryResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CREATED AT\tACTION\tACTOR\tCHANGES")
+	for _, revision := range result.Revisions {
+		actor := revision.Actor.Owner
+		if actor == "" {
+			actor = "-"
+		}
+		if revision.Actor.Transport != "" {
+			actor += " (" + string(revision.Actor.Transport) + ")"
+		}
+
+		changes := make([]string, 0, len(revision.Changes))
+		for _, change := range revision.Changes {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", change.Field, historyValue(change.Old), historyValue(change.New)))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			revision.CreatedAt.Format(time.RFC3339), revision.Action, actor, strings.Join(changes, "; "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if result.NextCursor != "" {
+		_, err := fmt.Fprintf(w, "\nNext cursor: %s\n", result.NextCursor)
+		return err
+	}
+
+	return nil
+}
+
+// historyValue prints the unset values of a change as a dash.
+func historyValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,305 @@
+package command
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// importRecord is a link of an import file, the CSV columns are named like the JSON fields.
+type importRecord struct {
+	URL    string `json:"url"`
+	Alias  string `json:"alias"`
+	Domain string `json:"domain"`
+	// RFC 3339 time
+	ExpiresAt string `json:"expires_at"`
+	// Go duration, e.g. 72h
+	TTL             string `json:"ttl"`
+	MaxHits         uint   `json:"max_hits"`
+	Password        string `json:"password"`
+	RedirectStatus  int    `json:"redirect_status"`
+	QueryForwarding string `json:"query_forwarding"`
+	ForwardPath     bool   `json:"forward_path"`
+	Disabled        bool   `json:"disabled"`
+}
+
+// importLine is a record of the file with its line number, err is set when the record cannot be read.
+type importLine struct {
+	line   int
+	url    string
+	action domain.CreateAction
+	err    error
+}
+
+func (h *shortCommand) newImportCommand() *cobra.Command {
+	var file string
+	var format string
+	var linkDomain string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create short URLs from a CSV or JSONL file",
+		Long: `Create short URLs from a CSV file with a header row or a JSONL file with one object per line.
+The columns, or fields, are: url, alias, domain, expires_at, ttl, max_hits, password,
+redirect_status, query_forwarding, forward_path and disabled, only url is required.
+
+The result of each link is printed as CSV: line, url, key, short_url and error.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if format == "" {
+				format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return fmt.Errorf("open file: %w", err)
+			}
+			defer f.Close()
+
+			var lines []importLine
+			switch format {
+			case "csv":
+				lines, err = readImportCSV(f)
+			case "jsonl", "ndjson":
+				lines, err = readImportJSONL(f)
+			default:
+				return errors.New("unknown format, expected csv or jsonl")
+			}
+			if err != nil {
+				return err
+			}
+
+			for i := range lines {
+				if lines[i].action.Domain == "" {
+					lines[i].action.Domain = linkDomain
+				}
+			}
+
+			out := csv.NewWriter(cmd.OutOrStdout())
+			if err := out.Write([]string{"line", "url", "key", "short_url", "error"}); err != nil {
+				return err
+			}
+
+			failed := 0
+			for start := 0; start < len(lines); start += domain.MaxBulkCreateItems {
+				batch := lines[start:min(start+domain.MaxBulkCreateItems, len(lines))]
+				results, err := h.createImportBatch(cmd, batch)
+				if err != nil {
+					return err
+				}
+
+				for i, result := range results {
+					row := []string{strconv.Itoa(batch[i].line), batch[i].url, result.Key, result.ShortURL, ""}
+					if batch[i].err != nil {
+						failed++
+						row[4] = batch[i].err.Error()
+					} else if result.Err != nil {
+						failed++
+						row[4] = mapShortLinkError(result.Err).Error()
+					}
+					if err := out.Write(row); err != nil {
+						return err
+					}
+				}
+				out.Flush()
+				if err := out.Error(); err != nil {
+					return err
+				}
+			}
+
+			_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Imported %d of %d links\n", len(lines)-failed, len(lines))
+			if err == nil && failed > 0 {
+				err = fmt.Errorf("%d links were not imported", failed)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "CSV or JSONL file of the links")
+	cmd.Flags().StringVar(&format, "format", "", "File format: csv or jsonl, inferred from the file extension when empty")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the links without one, the primary domain when empty")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// createImportBatch creates the readable links of the batch, the results follow the order of the batch.
+func (h *shortCommand) createImportBatch(cmd *cobra.Command, batch []importLine) ([]domain.BulkCreateResult, error) {
+	results := make([]domain.BulkCreateResult, len(batch))
+	actions := make([]domain.CreateAction, 0, len(batch))
+	indexes := make([]int, 0, len(batch))
+	for i, line := range batch {
+		// the unreadable records are reported by the caller
+		if line.err != nil {
+			continue
+		}
+
+		actions = append(actions, line.action)
+		indexes = append(indexes, i)
+	}
+	if len(actions) == 0 {
+		return results, nil
+	}
+
+	created, err := h.shortLinkUsecase.CreateMany(cmd.Context(), actions)
+	if err != nil {
+		return nil, mapShortLinkError(err)
+	}
+	for j, result := range created {
+		results[indexes[j]] = result
+	}
+
+	return results, nil
+}
+
+func readImportCSV(r io.Reader) ([]importLine, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, errors.New("the csv header has no url column")
+	}
+
+	var lines []importLine
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		record, err := newCSVImportRecord(func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		})
+		lines = append(lines, newImportLine(line, record, err))
+	}
+
+	return lines, nil
+}
+
+func newCSVImportRecord(value func(name string) string) (importRecord, error) {
+	record := importRecord{
+		URL:             value("url"),
+		Alias:           value("alias"),
+		Domain:          value("domain"),
+		ExpiresAt:       value("expires_at"),
+		TTL:             value("ttl"),
+		Password:        value("password"),
+		QueryForwarding: value("query_forwarding"),
+	}
+
+	if maxHits := value("max_hits"); maxHits != "" {
+		n, err := strconv.ParseUint(maxHits, 10, 0)
+		if err != nil {
+			return record, errors.New("invalid max_hits")
+		}
+		record.MaxHits = uint(n)
+	}
+	if redirectStatus := value("redirect_status"); redirectStatus != "" {
+		n, err := strconv.Atoi(redirectStatus)
+		if err != nil {
+			return record, errors.New("invalid redirect_status")
+		}
+		record.RedirectStatus = n
+	}
+	if forwardPath := value("forward_path"); forwardPath != "" {
+		b, err := strconv.ParseBool(forwardPath)
+		if err != nil {
+			return record, errors.New("invalid forward_path")
+		}
+		record.ForwardPath = b
+	}
+	if disabled := value("disabled"); disabled != "" {
+		b, err := strconv.ParseBool(disabled)
+		if err != nil {
+			return record, errors.New("invalid disabled")
+		}
+		record.Disabled = b
+	}
+
+	return record, nil
+}
+
+func readImportJSONL(r io.Reader) ([]importLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+
+	var lines []importLine
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record importRecord
+		var err error
+		if json.Unmarshal([]byte(text), &record) != nil {
+			err = errors.New("invalid JSON")
+		}
+		lines = append(lines, newImportLine(line, record, err))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read jsonl: %w", err)
+	}
+
+	return lines, nil
+}
+
+// newImportLine maps the record to the create action, err is the error of reading the record.
+func newImportLine(line int, record importRecord, err error) importLine {
+	if err != nil {
+		return importLine{line: line, url: record.URL, err: err}
+	}
+
+	action := domain.CreateAction{
+		OriginalURL:     record.URL,
+		Domain:          record.Domain,
+		Alias:           record.Alias,
+		MaxHits:         record.MaxHits,
+		Password:        record.Password,
+		RedirectStatus:  record.RedirectStatus,
+		QueryForwarding: domain.QueryForwarding(record.QueryForwarding),
+		ForwardPath:     record.ForwardPath,
+		Disabled:        record.Disabled,
+	}
+	if record.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, record.ExpiresAt)
+		if err != nil {
+			return importLine{line: line, url: record.URL, err: errors.New("invalid expires_at, expected RFC 3339 time")}
+		}
+		action.ExpiresAt = t
+	}
+	if record.TTL != "" {
+		ttl, err := time.ParseDuration(record.TTL)
+		if err != nil {
+			return importLine{line: line, url: record.URL, err: errors.New("invalid ttl")}
+		}
+		action.TTL = ttl
+	}
+
+	return importLine{line: line, url: record.URL, action: action}
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func (h *shortCommand) newRestoreCommand() *cobra.Command {
+	var key string
+	var linkDomain string
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a deleted short URL from the trash",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ent, err := h.shortLinkUsecase.Restore(cmd.Context(), domain.LinkRef{Domain: linkDomain, Key: key})
+			if err != nil {
+				if errors.Is(err, domain.ErrShortLinkNotFound) {
+					return errors.New("link not found in the trash")
+				}
+				return mapShortLinkError(err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Restored key: %s\nOriginal URL: %s\n", ent.Key, ent.OriginalURL)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
+
+func (h *shortCommand) newPurgeCommand() *cobra.Command {
+	var key string
+	var linkDomain string
+	var olderThan time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Permanently remove deleted short URLs from the trash",
+		Long: `Permanently remove a deleted short URL with --key, or every short URL deleted
+longer than --older-than ago. A purged key can be reissued and cannot be restored.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if key != "" {
+				err := h.shortLinkUsecase.Purge(cmd.Context(), domain.LinkRef{Domain: linkDomain, Key: key})
+				if err != nil {
+					if errors.Is(err, domain.ErrShortLinkNotFound) {
+						return errors.New("link not found in the trash")
+					}
+					return mapShortLinkError(err)
+				}
+
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Purged key: %s\n", key)
+				return err
+			}
+
+			purged, err := h.shortLinkUsecase.PurgeDeleted(cmd.Context(), olderThan)
+			if err != nil {
+				return mapShortLinkError(err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Purged %d links\n", purged)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&key, "key", "", "Short URL key")
+	cmd.Flags().StringVar(&linkDomain, "domain", "", "Short domain of the link, the primary domain when empty")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "Time in the trash of the purged links, e.g. 720h, 0 empties the trash")
+	cmd.MarkFlagsMutuallyExclusive("key", "older-than")
+	cmd.MarkFlagsOneRequired("key", "older-than")
+
+	return cmd
+}
@@ -0,0 +1,161 @@
+// Package export writes the exported links as CSV or JSONL, the same way on every transport.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// ParseFormat returns the format of the name, CSV when it is empty.
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "", string(FormatCSV):
+		return FormatCSV, nil
+	case string(FormatJSONL), "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	if f == FormatJSONL {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// csvHeader names the columns like the JSON fields.
+var csvHeader = []string{
+	"domain", "key", "url", "hits", "max_hits", "created_at", "expires_at",
+	"password_protected", "redirect_status", "query_forwarding", "forward_path", "disabled",
+}
+
+// linkRecord is a link in the JSONL export, the password hash is never exported.
+type linkRecord struct {
+	// Omitted for the primary domain
+	Domain            string     `json:"domain,omitempty"`
+	Key               string     `json:"key"`
+	URL               string     `json:"url"`
+	Hits              uint       `json:"hits"`
+	MaxHits           uint       `json:"max_hits,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	PasswordProtected bool       `json:"password_protected"`
+	RedirectStatus    int        `json:"redirect_status,omitempty"`
+	QueryForwarding   string     `json:"query_forwarding,omitempty"`
+	ForwardPath       bool       `json:"forward_path"`
+	Disabled          bool       `json:"disabled"`
+}
+
+// Writer writes the links one by one, the CSV header is written before the first link.
+type Writer struct {
+	format        Format
+	csvWriter     *csv.Writer
+	jsonEncoder   *json.Encoder
+	headerWritten bool
+}
+
+func NewWriter(w io.Writer, format Format) *Writer {
+	writer := &Writer{format: format}
+	if format == FormatJSONL {
+		writer.jsonEncoder = json.NewEncoder(w)
+	} else {
+		writer.csvWriter = csv.NewWriter(w)
+	}
+
+	return writer
+}
+
+func (w *Writer) Write(link domain.ShortLink) error {
+	if w.format == FormatJSONL {
+		return w.jsonEncoder.Encode(newLinkRecord(link))
+	}
+
+	if !w.headerWritten {
+		if err := w.csvWriter.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	expiresAt := ""
+	if !link.ExpiresAt.IsZero() {
+		expiresAt = link.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	redirectStatus := ""
+	if link.RedirectStatus != 0 {
+		redirectStatus = strconv.Itoa(link.RedirectStatus)
+	}
+
+	return w.csvWriter.Write([]string{
+		link.Domain,
+		link.Key,
+		link.OriginalURL,
+		strconv.FormatUint(uint64(link.Hits), 10),
+		strconv.FormatUint(uint64(link.MaxHits), 10),
+		link.CreatedAt.UTC().Format(time.RFC3339),
+		expiresAt,
+		strconv.FormatBool(link.IsProtected()),
+		redirectStatus,
+		string(link.QueryForwarding),
+		strconv.FormatBool(link.ForwardPath),
+		strconv.FormatBool(link.Disabled),
+	})
+}
+
+// Flush writes the buffered links, and the CSV header of an empty export.
+func (w *Writer) Flush() error {
+	if w.format == FormatJSONL {
+		return nil
+	}
+
+	if !w.headerWritten {
+		if err := w.csvWriter.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.csvWriter.Flush()
+
+	return w.csvWriter.Error()
+}
+
+func newLinkRecord(link domain.ShortLink) linkRecord {
+	record := linkRecord{
+		Domain:            link.Domain,
+		Key:               link.Key,
+		URL:               link.OriginalURL,
+		Hits:              link.Hits,
+		MaxHits:           link.MaxHits,
+		CreatedAt:         link.CreatedAt.UTC(),
+		PasswordProtected: link.IsProtected(),
+		RedirectStatus:    link.RedirectStatus,
+		QueryForwarding:   string(link.QueryForwarding),
+		ForwardPath:       link.ForwardPath,
+		Disabled:          link.Disabled,
+	}
+	if !link.ExpiresAt.IsZero() {
+		expiresAt := link.ExpiresAt.UTC()
+		record.ExpiresAt = &expiresAt
+	}
+
+	return record
+}
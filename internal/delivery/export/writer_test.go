@@ -0,0 +1,83 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/delivery/export"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestWriter(t *testing.T) {
+	createdAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	links := []domain.ShortLink{
+		{Key: "abc123", OriginalURL: "https://example.com/a,b", Hits: 7, CreatedAt: createdAt, PasswordHash: "hash"},
+		{Domain: "go.brand-a.com", Key: "sale", OriginalURL: "https://brand-a.com/sale", CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour), RedirectStatus: 301, QueryForwarding: domain.QueryForwardingKeep, ForwardPath: true, Disabled: true},
+	}
+
+	tests := []struct {
+		format   export.Format
+		links    []domain.ShortLink
+		expected string
+	}{
+		{
+			format: export.FormatCSV,
+			links:  links,
+			expected: "domain,key,url,hits,max_hits,created_at,expires_at,password_protected,redirect_status,query_forwarding,forward_path,disabled\n" +
+				",abc123,\"https://example.com/a,b\",7,0,2026-03-10T12:00:00Z,,true,,,false,false\n" +
+				"go.brand-a.com,sale,https://brand-a.com/sale,0,0,2026-03-10T12:00:00Z,2026-03-10T13:00:00Z,false,301,keep,true,true\n",
+		},
+		{
+			format:   export.FormatCSV,
+			expected: "domain,key,url,hits,max_hits,created_at,expires_at,password_protected,redirect_status,query_forwarding,forward_path,disabled\n",
+		},
+		{
+			format: export.FormatJSONL,
+			links:  links,
+			expected: `{"key":"abc123","url":"https://example.com/a,b","hits":7,"created_at":"2026-03-10T12:00:00Z","password_protected":true,"forward_path":false,"disabled":false}` + "\n" +
+				`{"domain":"go.brand-a.com","key":"sale","url":"https://brand-a.com/sale","hits":0,"created_at":"2026-03-10T12:00:00Z","expires_at":"2026-03-10T13:00:00Z","password_protected":false,"redirect_status":301,"query_forwarding":"keep","forward_path":true,"disabled":true}` + "\n",
+		},
+		{
+			format: export.FormatJSONL,
+		},
+	}
+
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		writer := export.NewWriter(buf, tt.format)
+		for _, link := range tt.links {
+			if err := writer.Write(link); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+
+		if got := buf.String(); got != tt.expected {
+			t.Errorf("%s export = %q; want %q", tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    export.Format
+		expectedErr error
+	}{
+		{name: "", expected: export.FormatCSV},
+		{name: "csv", expected: export.FormatCSV},
+		{name: "jsonl", expected: export.FormatJSONL},
+		{name: "ndjson", expected: export.FormatJSONL},
+		{name: "xlsx", expectedErr: export.ErrUnknownFormat},
+	}
+
+	for _, tt := range tests {
+		got, err := export.ParseFormat(tt.name)
+		if got != tt.expected || err != tt.expectedErr {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q, %v", tt.name, got, err, tt.expected, tt.expectedErr)
+		}
+	}
+}
@@ -12,13 +12,86 @@ func mapDomainError(err error) error {
 	switch {
 	case errors.Is(err, domain.ErrInvalidURL):
 		return status.Error(codes.InvalidArgument, "Invalid URL.")
+	case errors.Is(err, domain.ErrURLTooLong):
+		return status.Error(codes.InvalidArgument, "The URL is too long.")
+	case errors.Is(err, domain.ErrDomainNotAllowed):
+		return status.Error(codes.InvalidArgument, "The URL domain is not allowed.")
+	case errors.Is(err, domain.ErrPrivateAddress):
+		return status.Error(codes.InvalidArgument, "The URL points to a private network address.")
+	case errors.Is(err, domain.ErrShortenerURL):
+		return status.Error(codes.InvalidArgument, "The URL points to a URL shortener.")
 	case errors.Is(err, domain.ErrShortLinkNotFound):
 		return status.Error(codes.NotFound, "Link not found.")
+	case errors.Is(err, domain.ErrInvalidAlias):
+		return status.Error(codes.InvalidArgument, "Invalid alias.")
+	case errors.Is(err, domain.ErrReservedAlias):
+		return status.Error(codes.InvalidArgument, "The alias is reserved.")
+	case errors.Is(err, domain.ErrShortLinkKeyExists):
+		return status.Error(codes.AlreadyExists, "The alias is already taken.")
+	case errors.Is(err, domain.ErrInvalidExpiration):
+		return status.Error(codes.InvalidArgument, "Invalid expiration.")
+	case errors.Is(err, domain.ErrShortLinkExpired):
+		return status.Error(codes.FailedPrecondition, "Link expired.")
+	case errors.Is(err, domain.ErrShortLinkExhausted):
+		return status.Error(codes.FailedPrecondition, "Link hit limit reached.")
+	case errors.Is(err, domain.ErrShortLinkDisabled):
+		return status.Error(codes.FailedPrecondition, "Link disabled.")
+	case errors.Is(err, domain.ErrInvalidListQuery):
+		return status.Error(codes.InvalidArgument, "Invalid list query.")
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, "Invalid cursor.")
+	case errors.Is(err, domain.ErrInvalidStatsQuery):
+		return status.Error(codes.InvalidArgument, "Invalid stats query.")
+	case errors.Is(err, domain.ErrInvalidLinkPassword):
+		return status.Error(codes.InvalidArgument, "Invalid password.")
+	case errors.Is(err, domain.ErrPasswordRequired):
+		return status.Error(codes.Unauthenticated, "The link is password protected.")
+	case errors.Is(err, domain.ErrWrongPassword):
+		return status.Error(codes.PermissionDenied, "Wrong password.")
+	case errors.Is(err, domain.ErrTooManyPasswordAttempts):
+		return status.Error(codes.ResourceExhausted, "Too many password attempts.")
+	case errors.Is(err, domain.ErrInvalidRedirectStatus):
+		return status.Error(codes.InvalidArgument, "Invalid redirect status.")
+	case errors.Is(err, domain.ErrInvalidQueryForwarding):
+		return status.Error(codes.InvalidArgument, "Invalid query forwarding.")
+	case errors.Is(err, domain.ErrUnknownDomain):
+		return status.Error(codes.InvalidArgument, "Unknown domain.")
+	case errors.Is(err, domain.ErrInvalidQRCodeQuery):
+		return status.Error(codes.InvalidArgument, "Invalid QR code query.")
+	case errors.Is(err, domain.ErrQRCodeLogoNotConfigured):
+		return status.Error(codes.FailedPrecondition, "No QR code logo is configured.")
+	case errors.Is(err, domain.ErrInvalidBatchSize):
+		return status.Error(codes.InvalidArgument, "Invalid batch size.")
 	default:
 		return status.Error(codes.Internal, "Internal server error.")
 	}
 }
 
 func isHandledDomainError(err error) bool {
-	return errors.Is(err, domain.ErrInvalidURL) || errors.Is(err, domain.ErrShortLinkNotFound)
+	return errors.Is(err, domain.ErrInvalidURL) ||
+		errors.Is(err, domain.ErrURLTooLong) ||
+		errors.Is(err, domain.ErrDomainNotAllowed) ||
+		errors.Is(err, domain.ErrPrivateAddress) ||
+		errors.Is(err, domain.ErrShortenerURL) ||
+		errors.Is(err, domain.ErrShortLinkNotFound) ||
+		errors.Is(err, domain.ErrInvalidAlias) ||
+		errors.Is(err, domain.ErrReservedAlias) ||
+		errors.Is(err, domain.ErrShortLinkKeyExists) ||
+		errors.Is(err, domain.ErrInvalidExpiration) ||
+		errors.Is(err, domain.ErrShortLinkExpired) ||
+		errors.Is(err, domain.ErrShortLinkExhausted) ||
+		errors.Is(err, domain.ErrShortLinkDisabled) ||
+		errors.Is(err, domain.ErrInvalidListQuery) ||
+		errors.Is(err, domain.ErrInvalidCursor) ||
+		errors.Is(err, domain.ErrInvalidStatsQuery) ||
+		errors.Is(err, domain.ErrInvalidLinkPassword) ||
+		errors.Is(err, domain.ErrPasswordRequired) ||
+		errors.Is(err, domain.ErrWrongPassword) ||
+		errors.Is(err, domain.ErrTooManyPasswordAttempts) ||
+		errors.Is(err, domain.ErrInvalidRedirectStatus) ||
+		errors.Is(err, domain.ErrInvalidQueryForwarding) ||
+		errors.Is(err, domain.ErrUnknownDomain) ||
+		errors.Is(err, domain.ErrInvalidQRCodeQuery) ||
+		errors.Is(err, domain.ErrQRCodeLogoNotConfigured) ||
+		errors.Is(err, domain.ErrInvalidBatchSize)
 }
@@ -0,0 +1,38 @@
+package grpcdelivery
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestMapDomainError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+		handled      bool
+	}{
+		{name: "Not Found", err: domain.ErrShortLinkNotFound, expectedCode: codes.NotFound, handled: true},
+		{name: "Expired", err: domain.ErrShortLinkExpired, expectedCode: codes.FailedPrecondition, handled: true},
+		{name: "Disabled", err: domain.ErrShortLinkDisabled, expectedCode: codes.FailedPrecondition, handled: true},
+		{name: "Wrapped Disabled", err: fmt.Errorf("Usecase.Expand (link: abc): %w", domain.ErrShortLinkDisabled), expectedCode: codes.FailedPrecondition, handled: true},
+		{name: "Unknown", err: errors.New("connection refused"), expectedCode: codes.Internal, handled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(mapDomainError(tt.err)); code != tt.expectedCode {
+				t.Errorf("mapDomainError(%v) code = %v; want %v", tt.err, code, tt.expectedCode)
+			}
+			if handled := isHandledDomainError(tt.err); handled != tt.handled {
+				t.Errorf("isHandledDomainError(%v) = %t; want %t", tt.err, handled, tt.handled)
+			}
+		})
+	}
+}
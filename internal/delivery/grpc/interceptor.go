@@ -2,14 +2,18 @@ package grpcdelivery
 
 import (
 	"context"
-	"crypto/subtle"
 	"log/slog"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/clientip"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -46,40 +50,258 @@ func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
-func authenticationUnaryInterceptor(apiSecret string, protectedMethods map[string]struct{}) grpc.UnaryServerInterceptor {
+func recoveryStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recoveredErr := recover(); recoveredErr != nil {
+				logger.Error("grpc panic recovered",
+					slog.String("method", info.FullMethod),
+					slog.Any("error", recoveredErr),
+				)
+				err = status.Error(codes.Internal, "Internal server error.")
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		duration := time.Since(start)
+
+		grpcCode := status.Code(err)
+		logger.Info("grpc stream handled",
+			slog.String("method", info.FullMethod),
+			slog.String("code", grpcCode.String()),
+			slog.Duration("duration", duration),
+		)
+
+		return err
+	}
+}
+
+// transportUnaryInterceptor marks the calls as gRPC ones, for the actor recorded in the history of the links.
+func transportUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(domain.ContextWithTransport(ctx, domain.TransportGRPC), request)
+	}
+}
+
+// transportStreamInterceptor is the transportUnaryInterceptor of the streaming methods.
+func transportStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := domain.ContextWithTransport(stream.Context(), domain.TransportGRPC)
+
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticationUnaryInterceptor requires an API key or a JWT granting the scope of each protected method.
+// The authenticated caller is available to the handlers through domain.PrincipalFromContext.
+func authenticationUnaryInterceptor(
+	logger *slog.Logger,
+	authUsecase domain.AuthUsecase,
+	protectedMethods map[string]domain.Scope,
+) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		if _, shouldAuthenticate := protectedMethods[info.FullMethod]; !shouldAuthenticate {
+		scope, shouldAuthenticate := protectedMethods[info.FullMethod]
+		if !shouldAuthenticate {
 			return handler(ctx, request)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "The auth token is missing.")
+		ctx, err := authenticate(ctx, logger, authUsecase, info.FullMethod, scope)
+		if err != nil {
+			return nil, err
 		}
 
-		authHeader := ""
-		for _, value := range md.Get("authorization") {
-			trimmedValue := strings.TrimSpace(value)
-			if trimmedValue != "" {
-				authHeader = trimmedValue
-				break
-			}
+		return handler(ctx, request)
+	}
+}
+
+// authenticationStreamInterceptor is the authenticationUnaryInterceptor of the streaming methods.
+func authenticationStreamInterceptor(
+	logger *slog.Logger,
+	authUsecase domain.AuthUsecase,
+	protectedMethods map[string]domain.Scope,
+) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		scope, shouldAuthenticate := protectedMethods[info.FullMethod]
+		if !shouldAuthenticate {
+			return handler(srv, stream)
 		}
 
-		if authHeader == "" {
-			return nil, status.Error(codes.Unauthenticated, "The auth token is missing.")
+		ctx, err := authenticate(stream.Context(), logger, authUsecase, info.FullMethod, scope)
+		if err != nil {
+			return err
 		}
 
-		authParts := strings.Fields(authHeader)
-		if len(authParts) != 2 || !strings.EqualFold(authParts[0], "Bearer") {
-			return nil, status.Error(codes.Unauthenticated, "Invalid Authorization header.")
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate returns the context holding the principal of the call, when it is granted the scope.
+func authenticate(
+	ctx context.Context,
+	logger *slog.Logger,
+	authUsecase domain.AuthUsecase,
+	method string,
+	scope domain.Scope,
+) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "The auth token is missing.")
+	}
+
+	authHeader := ""
+	for _, value := range md.Get("authorization") {
+		trimmedValue := strings.TrimSpace(value)
+		if trimmedValue != "" {
+			authHeader = trimmedValue
+			break
 		}
+	}
 
-		token := authParts[1]
-		if subtle.ConstantTimeCompare([]byte(token), []byte(apiSecret)) != 1 {
+	if authHeader == "" {
+		return nil, status.Error(codes.Unauthenticated, "The auth token is missing.")
+	}
+
+	authParts := strings.Fields(authHeader)
+	if len(authParts) != 2 || !strings.EqualFold(authParts[0], "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "Invalid Authorization header.")
+	}
+
+	principal, err := authUsecase.Authenticate(ctx, authParts[1])
+	if err != nil {
+		if err == domain.ErrInvalidToken {
 			return nil, status.Error(codes.Unauthenticated, "Invalid token.")
 		}
 
+		logger.Error("grpc authentication failed",
+			slog.String("method", method),
+			slog.Any("error", err),
+		)
+		return nil, status.Error(codes.Internal, "Internal server error.")
+	}
+
+	if !principal.HasScope(scope) {
+		return nil, status.Error(codes.PermissionDenied, "The token lacks the required scope.")
+	}
+
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+// contextServerStream replaces the context of a stream, like the context passed on by a unary interceptor.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// rateLimitedRoute is the limit of a method and the route whose counters it uses.
+// The routes are named like the HTTP ones, so a client has the same budget on both transports.
+type rateLimitedRoute struct {
+	route string
+	limit domain.RateLimit
+}
+
+// rateLimitUnaryInterceptor rejects the calls of a client above the limit of the method.
+// The client is the authenticated principal when the interceptor runs after the authentication,
+// otherwise the client IP. The calls are allowed when the limiter is unavailable.
+func rateLimitUnaryInterceptor(
+	logger *slog.Logger,
+	limiter domain.RateLimiter,
+	routes map[string]rateLimitedRoute,
+	trustProxy bool,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := allowCall(ctx, logger, limiter, routes, info.FullMethod, trustProxy); err != nil {
+			return nil, err
+		}
+
 		return handler(ctx, request)
 	}
 }
+
+// rateLimitStreamInterceptor is the rateLimitUnaryInterceptor of the streaming methods, a stream counts as one call.
+func rateLimitStreamInterceptor(
+	logger *slog.Logger,
+	limiter domain.RateLimiter,
+	routes map[string]rateLimitedRoute,
+	trustProxy bool,
+) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allowCall(stream.Context(), logger, limiter, routes, info.FullMethod, trustProxy); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// allowCall returns the error rejecting the call when the client is above the limit of the method.
+func allowCall(
+	ctx context.Context,
+	logger *slog.Logger,
+	limiter domain.RateLimiter,
+	routes map[string]rateLimitedRoute,
+	method string,
+	trustProxy bool,
+) error {
+	route, ok := routes[method]
+	if !ok || !route.limit.Enabled() {
+		return nil
+	}
+
+	client := "ip:" + clientIP(ctx, trustProxy)
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		client = "owner:" + principal.Owner
+	}
+
+	result, err := limiter.Allow(ctx, route.route, client, route.limit)
+	if err != nil {
+		logger.Warn("rate limiter is unavailable, allowing the call",
+			slog.String("method", method),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+
+	if !result.Allowed {
+		retryAfter := max(1, int(math.Ceil(result.RetryAfter.Seconds())))
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+		return status.Error(codes.ResourceExhausted, "Too many requests.")
+	}
+
+	return nil
+}
+
+// clientIP returns the IP of the caller, read from the proxy metadata when the proxy is trusted.
+func clientIP(ctx context.Context, trustProxy bool) string {
+	if trustProxy {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			// the metadata keys are the lowercase header names
+			ip := clientip.FromProxyHeaders(func(name string) string {
+				if values := md.Get(name); len(values) > 0 {
+					return values[0]
+				}
+				return ""
+			})
+			if ip != "" {
+				return ip
+			}
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return clientip.FromRemoteAddr(p.Addr.String())
+}
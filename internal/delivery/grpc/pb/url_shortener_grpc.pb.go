@@ -20,10 +20,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortLinkService_CreateShortLink_FullMethodName   = "/urlshortener.v1.ShortLinkService/CreateShortLink"
-	ShortLinkService_DeleteShortLink_FullMethodName   = "/urlshortener.v1.ShortLinkService/DeleteShortLink"
-	ShortLinkService_ExpandShortLink_FullMethodName   = "/urlshortener.v1.ShortLinkService/ExpandShortLink"
-	ShortLinkService_GetShortLinkStats_FullMethodName = "/urlshortener.v1.ShortLinkService/GetShortLinkStats"
+	ShortLinkService_CreateShortLink_FullMethodName        = "/urlshortener.v1.ShortLinkService/CreateShortLink"
+	ShortLinkService_CreateShortLinks_FullMethodName       = "/urlshortener.v1.ShortLinkService/CreateShortLinks"
+	ShortLinkService_UpdateShortLink_FullMethodName        = "/urlshortener.v1.ShortLinkService/UpdateShortLink"
+	ShortLinkService_DeleteShortLink_FullMethodName        = "/urlshortener.v1.ShortLinkService/DeleteShortLink"
+	ShortLinkService_RestoreShortLink_FullMethodName       = "/urlshortener.v1.ShortLinkService/RestoreShortLink"
+	ShortLinkService_DisableShortLink_FullMethodName       = "/urlshortener.v1.ShortLinkService/DisableShortLink"
+	ShortLinkService_EnableShortLink_FullMethodName        = "/urlshortener.v1.ShortLinkService/EnableShortLink"
+	ShortLinkService_ExpandShortLink_FullMethodName        = "/urlshortener.v1.ShortLinkService/ExpandShortLink"
+	ShortLinkService_GetShortLinkStats_FullMethodName      = "/urlshortener.v1.ShortLinkService/GetShortLinkStats"
+	ShortLinkService_ListShortLinks_FullMethodName         = "/urlshortener.v1.ShortLinkService/ListShortLinks"
+	ShortLinkService_ListClickEvents_FullMethodName        = "/urlshortener.v1.ShortLinkService/ListClickEvents"
+	ShortLinkService_ListShortLinkRevisions_FullMethodName = "/urlshortener.v1.ShortLinkService/ListShortLinkRevisions"
+	ShortLinkService_GetShortLinkQRCode_FullMethodName     = "/urlshortener.v1.ShortLinkService/GetShortLinkQRCode"
 )
 
 // ShortLinkServiceClient is the client API for ShortLinkService service.
@@ -31,9 +40,23 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ShortLinkServiceClient interface {
 	CreateShortLink(ctx context.Context, in *CreateShortLinkRequest, opts ...grpc.CallOption) (*CreateShortLinkResponse, error)
+	// Creates the streamed links in batches, the results of a batch are sent once it is full
+	// or the client closes its side of the stream.
+	CreateShortLinks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CreateShortLinkRequest, CreateShortLinksResponse], error)
+	UpdateShortLink(ctx context.Context, in *UpdateShortLinkRequest, opts ...grpc.CallOption) (*UpdateShortLinkResponse, error)
+	// Moves the link to the trash, it can be restored until it is purged.
 	DeleteShortLink(ctx context.Context, in *DeleteShortLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreShortLink(ctx context.Context, in *RestoreShortLinkRequest, opts ...grpc.CallOption) (*RestoreShortLinkResponse, error)
+	// Pauses the link without deleting it, it keeps its key and stats but is not followed until it is enabled.
+	DisableShortLink(ctx context.Context, in *DisableShortLinkRequest, opts ...grpc.CallOption) (*DisableShortLinkResponse, error)
+	EnableShortLink(ctx context.Context, in *EnableShortLinkRequest, opts ...grpc.CallOption) (*EnableShortLinkResponse, error)
 	ExpandShortLink(ctx context.Context, in *ExpandShortLinkRequest, opts ...grpc.CallOption) (*ExpandShortLinkResponse, error)
 	GetShortLinkStats(ctx context.Context, in *GetShortLinkStatsRequest, opts ...grpc.CallOption) (*GetShortLinkStatsResponse, error)
+	ListShortLinks(ctx context.Context, in *ListShortLinksRequest, opts ...grpc.CallOption) (*ListShortLinksResponse, error)
+	ListClickEvents(ctx context.Context, in *ListClickEventsRequest, opts ...grpc.CallOption) (*ListClickEventsResponse, error)
+	// Lists the changes of the links which used the key, including the deleted and purged ones.
+	ListShortLinkRevisions(ctx context.Context, in *ListShortLinkRevisionsRequest, opts ...grpc.CallOption) (*ListShortLinkRevisionsResponse, error)
+	GetShortLinkQRCode(ctx context.Context, in *GetShortLinkQRCodeRequest, opts ...grpc.CallOption) (*GetShortLinkQRCodeResponse, error)
 }
 
 type shortLinkServiceClient struct {
@@ -54,6 +77,29 @@ func (c *shortLinkServiceClient) CreateShortLink(ctx context.Context, in *Create
 	return out, nil
 }
 
+func (c *shortLinkServiceClient) CreateShortLinks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CreateShortLinkRequest, CreateShortLinksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortLinkService_ServiceDesc.Streams[0], ShortLinkService_CreateShortLinks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateShortLinkRequest, CreateShortLinksResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortLinkService_CreateShortLinksClient = grpc.BidiStreamingClient[CreateShortLinkRequest, CreateShortLinksResponse]
+
+func (c *shortLinkServiceClient) UpdateShortLink(ctx context.Context, in *UpdateShortLinkRequest, opts ...grpc.CallOption) (*UpdateShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_UpdateShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortLinkServiceClient) DeleteShortLink(ctx context.Context, in *DeleteShortLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -64,6 +110,36 @@ func (c *shortLinkServiceClient) DeleteShortLink(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *shortLinkServiceClient) RestoreShortLink(ctx context.Context, in *RestoreShortLinkRequest, opts ...grpc.CallOption) (*RestoreShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_RestoreShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) DisableShortLink(ctx context.Context, in *DisableShortLinkRequest, opts ...grpc.CallOption) (*DisableShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_DisableShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) EnableShortLink(ctx context.Context, in *EnableShortLinkRequest, opts ...grpc.CallOption) (*EnableShortLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableShortLinkResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_EnableShortLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortLinkServiceClient) ExpandShortLink(ctx context.Context, in *ExpandShortLinkRequest, opts ...grpc.CallOption) (*ExpandShortLinkResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ExpandShortLinkResponse)
@@ -84,14 +160,68 @@ func (c *shortLinkServiceClient) GetShortLinkStats(ctx context.Context, in *GetS
 	return out, nil
 }
 
+func (c *shortLinkServiceClient) ListShortLinks(ctx context.Context, in *ListShortLinksRequest, opts ...grpc.CallOption) (*ListShortLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShortLinksResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_ListShortLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) ListClickEvents(ctx context.Context, in *ListClickEventsRequest, opts ...grpc.CallOption) (*ListClickEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClickEventsResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_ListClickEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) ListShortLinkRevisions(ctx context.Context, in *ListShortLinkRevisionsRequest, opts ...grpc.CallOption) (*ListShortLinkRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShortLinkRevisionsResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_ListShortLinkRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) GetShortLinkQRCode(ctx context.Context, in *GetShortLinkQRCodeRequest, opts ...grpc.CallOption) (*GetShortLinkQRCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShortLinkQRCodeResponse)
+	err := c.cc.Invoke(ctx, ShortLinkService_GetShortLinkQRCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortLinkServiceServer is the server API for ShortLinkService service.
 // All implementations must embed UnimplementedShortLinkServiceServer
 // for forward compatibility.
 type ShortLinkServiceServer interface {
 	CreateShortLink(context.Context, *CreateShortLinkRequest) (*CreateShortLinkResponse, error)
+	// Creates the streamed links in batches, the results of a batch are sent once it is full
+	// or the client closes its side of the stream.
+	CreateShortLinks(grpc.BidiStreamingServer[CreateShortLinkRequest, CreateShortLinksResponse]) error
+	UpdateShortLink(context.Context, *UpdateShortLinkRequest) (*UpdateShortLinkResponse, error)
+	// Moves the link to the trash, it can be restored until it is purged.
 	DeleteShortLink(context.Context, *DeleteShortLinkRequest) (*emptypb.Empty, error)
+	RestoreShortLink(context.Context, *RestoreShortLinkRequest) (*RestoreShortLinkResponse, error)
+	// Pauses the link without deleting it, it keeps its key and stats but is not followed until it is enabled.
+	DisableShortLink(context.Context, *DisableShortLinkRequest) (*DisableShortLinkResponse, error)
+	EnableShortLink(context.Context, *EnableShortLinkRequest) (*EnableShortLinkResponse, error)
 	ExpandShortLink(context.Context, *ExpandShortLinkRequest) (*ExpandShortLinkResponse, error)
 	GetShortLinkStats(context.Context, *GetShortLinkStatsRequest) (*GetShortLinkStatsResponse, error)
+	ListShortLinks(context.Context, *ListShortLinksRequest) (*ListShortLinksResponse, error)
+	ListClickEvents(context.Context, *ListClickEventsRequest) (*ListClickEventsResponse, error)
+	// Lists the changes of the links which used the key, including the deleted and purged ones.
+	ListShortLinkRevisions(context.Context, *ListShortLinkRevisionsRequest) (*ListShortLinkRevisionsResponse, error)
+	GetShortLinkQRCode(context.Context, *GetShortLinkQRCodeRequest) (*GetShortLinkQRCodeResponse, error)
 	mustEmbedUnimplementedShortLinkServiceServer()
 }
 
@@ -105,15 +235,42 @@ type UnimplementedShortLinkServiceServer struct{}
 func (UnimplementedShortLinkServiceServer) CreateShortLink(context.Context, *CreateShortLinkRequest) (*CreateShortLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShortLink not implemented")
 }
+func (UnimplementedShortLinkServiceServer) CreateShortLinks(grpc.BidiStreamingServer[CreateShortLinkRequest, CreateShortLinksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateShortLinks not implemented")
+}
+func (UnimplementedShortLinkServiceServer) UpdateShortLink(context.Context, *UpdateShortLinkRequest) (*UpdateShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShortLink not implemented")
+}
 func (UnimplementedShortLinkServiceServer) DeleteShortLink(context.Context, *DeleteShortLinkRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteShortLink not implemented")
 }
+func (UnimplementedShortLinkServiceServer) RestoreShortLink(context.Context, *RestoreShortLinkRequest) (*RestoreShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreShortLink not implemented")
+}
+func (UnimplementedShortLinkServiceServer) DisableShortLink(context.Context, *DisableShortLinkRequest) (*DisableShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableShortLink not implemented")
+}
+func (UnimplementedShortLinkServiceServer) EnableShortLink(context.Context, *EnableShortLinkRequest) (*EnableShortLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableShortLink not implemented")
+}
 func (UnimplementedShortLinkServiceServer) ExpandShortLink(context.Context, *ExpandShortLinkRequest) (*ExpandShortLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExpandShortLink not implemented")
 }
 func (UnimplementedShortLinkServiceServer) GetShortLinkStats(context.Context, *GetShortLinkStatsRequest) (*GetShortLinkStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetShortLinkStats not implemented")
 }
+func (UnimplementedShortLinkServiceServer) ListShortLinks(context.Context, *ListShortLinksRequest) (*ListShortLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShortLinks not implemented")
+}
+func (UnimplementedShortLinkServiceServer) ListClickEvents(context.Context, *ListClickEventsRequest) (*ListClickEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClickEvents not implemented")
+}
+func (UnimplementedShortLinkServiceServer) ListShortLinkRevisions(context.Context, *ListShortLinkRevisionsRequest) (*ListShortLinkRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShortLinkRevisions not implemented")
+}
+func (UnimplementedShortLinkServiceServer) GetShortLinkQRCode(context.Context, *GetShortLinkQRCodeRequest) (*GetShortLinkQRCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShortLinkQRCode not implemented")
+}
 func (UnimplementedShortLinkServiceServer) mustEmbedUnimplementedShortLinkServiceServer() {}
 func (UnimplementedShortLinkServiceServer) testEmbeddedByValue()                          {}
 
@@ -153,6 +310,31 @@ func _ShortLinkService_CreateShortLink_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortLinkService_CreateShortLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShortLinkServiceServer).CreateShortLinks(&grpc.GenericServerStream[CreateShortLinkRequest, CreateShortLinksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortLinkService_CreateShortLinksServer = grpc.BidiStreamingServer[CreateShortLinkRequest, CreateShortLinksResponse]
+
+func _ShortLinkService_UpdateShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).UpdateShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_UpdateShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).UpdateShortLink(ctx, req.(*UpdateShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShortLinkService_DeleteShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteShortLinkRequest)
 	if err := dec(in); err != nil {
@@ -171,6 +353,60 @@ func _ShortLinkService_DeleteShortLink_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortLinkService_RestoreShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).RestoreShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_RestoreShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).RestoreShortLink(ctx, req.(*RestoreShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_DisableShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).DisableShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_DisableShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).DisableShortLink(ctx, req.(*DisableShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_EnableShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableShortLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).EnableShortLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_EnableShortLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).EnableShortLink(ctx, req.(*EnableShortLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShortLinkService_ExpandShortLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExpandShortLinkRequest)
 	if err := dec(in); err != nil {
@@ -207,6 +443,78 @@ func _ShortLinkService_GetShortLinkStats_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortLinkService_ListShortLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShortLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).ListShortLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_ListShortLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).ListShortLinks(ctx, req.(*ListShortLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_ListClickEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClickEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).ListClickEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_ListClickEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).ListClickEvents(ctx, req.(*ListClickEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_ListShortLinkRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShortLinkRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).ListShortLinkRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_ListShortLinkRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).ListShortLinkRevisions(ctx, req.(*ListShortLinkRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_GetShortLinkQRCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShortLinkQRCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).GetShortLinkQRCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortLinkService_GetShortLinkQRCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).GetShortLinkQRCode(ctx, req.(*GetShortLinkQRCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortLinkService_ServiceDesc is the grpc.ServiceDesc for ShortLinkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -218,10 +526,26 @@ var ShortLinkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateShortLink",
 			Handler:    _ShortLinkService_CreateShortLink_Handler,
 		},
+		{
+			MethodName: "UpdateShortLink",
+			Handler:    _ShortLinkService_UpdateShortLink_Handler,
+		},
 		{
 			MethodName: "DeleteShortLink",
 			Handler:    _ShortLinkService_DeleteShortLink_Handler,
 		},
+		{
+			MethodName: "RestoreShortLink",
+			Handler:    _ShortLinkService_RestoreShortLink_Handler,
+		},
+		{
+			MethodName: "DisableShortLink",
+			Handler:    _ShortLinkService_DisableShortLink_Handler,
+		},
+		{
+			MethodName: "EnableShortLink",
+			Handler:    _ShortLinkService_EnableShortLink_Handler,
+		},
 		{
 			MethodName: "ExpandShortLink",
 			Handler:    _ShortLinkService_ExpandShortLink_Handler,
@@ -230,8 +554,31 @@ var ShortLinkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetShortLinkStats",
 			Handler:    _ShortLinkService_GetShortLinkStats_Handler,
 		},
+		{
+			MethodName: "ListShortLinks",
+			Handler:    _ShortLinkService_ListShortLinks_Handler,
+		},
+		{
+			MethodName: "ListClickEvents",
+			Handler:    _ShortLinkService_ListClickEvents_Handler,
+		},
+		{
+			MethodName: "ListShortLinkRevisions",
+			Handler:    _ShortLinkService_ListShortLinkRevisions_Handler,
+		},
+		{
+			MethodName: "GetShortLinkQRCode",
+			Handler:    _ShortLinkService_GetShortLinkQRCode_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateShortLinks",
+			Handler:       _ShortLinkService_CreateShortLinks_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/url_shortener.proto",
 }
 
@@ -8,25 +8,70 @@ import (
 	"google.golang.org/grpc"
 )
 
+// RateLimits are the limits of the methods which may be abused, a zero limit disables the limiting.
+// Each limit is counted together with the HTTP routes of the same kind.
+type RateLimits struct {
+	Create domain.RateLimit
+	// Limit of the CreateShortLinks streams, a stream counts as one call
+	Bulk   domain.RateLimit
+	Expand domain.RateLimit
+	// Limit of the stats and the click events
+	Stats domain.RateLimit
+	QR    domain.RateLimit
+}
+
 func NewServer(
 	logger *slog.Logger,
-	apiSecret string,
+	authUsecase domain.AuthUsecase,
+	rateLimiter domain.RateLimiter,
+	rateLimits RateLimits,
 	shortLinkUsecase domain.ShortLinkUsecase,
 	healthUsecase domain.HealthUsecase,
+	// trust the client IP metadata set by a reverse proxy (cf-connecting-ip, x-forwarded-for, x-real-ip)
+	trustProxy bool,
 ) *grpc.Server {
+	protectedMethods := map[string]domain.Scope{
+		pb.ShortLinkService_CreateShortLink_FullMethodName:        domain.ScopeLinksCreate,
+		pb.ShortLinkService_CreateShortLinks_FullMethodName:       domain.ScopeLinksCreate,
+		pb.ShortLinkService_UpdateShortLink_FullMethodName:        domain.ScopeLinksUpdate,
+		pb.ShortLinkService_DeleteShortLink_FullMethodName:        domain.ScopeLinksDelete,
+		pb.ShortLinkService_RestoreShortLink_FullMethodName:       domain.ScopeLinksDelete,
+		pb.ShortLinkService_DisableShortLink_FullMethodName:       domain.ScopeLinksUpdate,
+		pb.ShortLinkService_EnableShortLink_FullMethodName:        domain.ScopeLinksUpdate,
+		pb.ShortLinkService_GetShortLinkStats_FullMethodName:      domain.ScopeLinksStats,
+		pb.ShortLinkService_ListShortLinks_FullMethodName:         domain.ScopeLinksRead,
+		pb.ShortLinkService_ListClickEvents_FullMethodName:        domain.ScopeLinksStats,
+		pb.ShortLinkService_ListShortLinkRevisions_FullMethodName: domain.ScopeLinksRead,
+		pb.ShortLinkService_GetShortLinkQRCode_FullMethodName:     domain.ScopeLinksRead,
+	}
+	rateLimitedRoutes := map[string]rateLimitedRoute{
+		pb.ShortLinkService_CreateShortLink_FullMethodName:    {route: "create", limit: rateLimits.Create},
+		pb.ShortLinkService_CreateShortLinks_FullMethodName:   {route: "bulk", limit: rateLimits.Bulk},
+		pb.ShortLinkService_ExpandShortLink_FullMethodName:    {route: "expand", limit: rateLimits.Expand},
+		pb.ShortLinkService_GetShortLinkStats_FullMethodName:  {route: "stats", limit: rateLimits.Stats},
+		pb.ShortLinkService_ListClickEvents_FullMethodName:    {route: "stats", limit: rateLimits.Stats},
+		pb.ShortLinkService_GetShortLinkQRCode_FullMethodName: {route: "qr", limit: rateLimits.QR},
+	}
+
 	server := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			recoveryUnaryInterceptor(logger),
 			loggingUnaryInterceptor(logger),
-			authenticationUnaryInterceptor(apiSecret, map[string]struct{}{
-				pb.ShortLinkService_CreateShortLink_FullMethodName:   {},
-				pb.ShortLinkService_DeleteShortLink_FullMethodName:   {},
-				pb.ShortLinkService_GetShortLinkStats_FullMethodName: {},
-			}),
+			transportUnaryInterceptor(),
+			authenticationUnaryInterceptor(logger, authUsecase, protectedMethods),
+			// after the authentication, so the links are created within the limit of each key
+			rateLimitUnaryInterceptor(logger, rateLimiter, rateLimitedRoutes, trustProxy),
+		),
+		grpc.ChainStreamInterceptor(
+			recoveryStreamInterceptor(logger),
+			loggingStreamInterceptor(logger),
+			transportStreamInterceptor(),
+			authenticationStreamInterceptor(logger, authUsecase, protectedMethods),
+			rateLimitStreamInterceptor(logger, rateLimiter, rateLimitedRoutes, trustProxy),
 		),
 	)
 
-	pb.RegisterShortLinkServiceServer(server, newShortLinkServer(logger, shortLinkUsecase))
+	pb.RegisterShortLinkServiceServer(server, newShortLinkServer(logger, shortLinkUsecase, trustProxy))
 	pb.RegisterHealthServiceServer(server, newHealthServer(healthUsecase))
 
 	return server
@@ -2,10 +2,13 @@ package grpcdelivery
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"time"
 
 	"github.com/OsoianMarcel/url-shortener/internal/delivery/grpc/pb"
 	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -14,16 +17,21 @@ import (
 
 var _ pb.ShortLinkServiceServer = (*shortLinkServer)(nil)
 
+// createShortLinksBatchSize is the number of streamed links created together.
+const createShortLinksBatchSize = 100
+
 type shortLinkServer struct {
 	pb.UnimplementedShortLinkServiceServer
-	logger  *slog.Logger
-	usecase domain.ShortLinkUsecase
+	logger     *slog.Logger
+	usecase    domain.ShortLinkUsecase
+	trustProxy bool
 }
 
-func newShortLinkServer(logger *slog.Logger, usecase domain.ShortLinkUsecase) *shortLinkServer {
+func newShortLinkServer(logger *slog.Logger, usecase domain.ShortLinkUsecase, trustProxy bool) *shortLinkServer {
 	return &shortLinkServer{
-		logger:  logger,
-		usecase: usecase,
+		logger:     logger,
+		usecase:    usecase,
+		trustProxy: trustProxy,
 	}
 }
 
@@ -32,7 +40,7 @@ func (s *shortLinkServer) CreateShortLink(ctx context.Context, request *pb.Creat
 		return nil, status.Error(codes.InvalidArgument, "Request is required.")
 	}
 
-	result, err := s.usecase.Create(ctx, domain.CreateAction{OriginalURL: request.GetUrl()})
+	result, err := s.usecase.Create(ctx, newCreateAction(request))
 	if err != nil {
 		if !isHandledDomainError(err) {
 			s.logger.Error("GRPC.CreateShortLink", slog.Any("error", err))
@@ -41,10 +49,128 @@ func (s *shortLinkServer) CreateShortLink(ctx context.Context, request *pb.Creat
 		return nil, mapDomainError(err)
 	}
 
-	return &pb.CreateShortLinkResponse{
-		ShortUrl: result.ShortURL,
-		Key:      result.Key,
-	}, nil
+	return toCreateShortLinkResponse(result), nil
+}
+
+func (s *shortLinkServer) CreateShortLinks(stream grpc.BidiStreamingServer[pb.CreateShortLinkRequest, pb.CreateShortLinksResponse]) error {
+	batch := make([]domain.CreateAction, 0, createShortLinksBatchSize)
+	// index of the first link of the batch in the stream
+	first := 0
+
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, newCreateAction(request))
+		if len(batch) == createShortLinksBatchSize {
+			if err := s.createShortLinksBatch(stream, batch, first); err != nil {
+				return err
+			}
+			first += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return s.createShortLinksBatch(stream, batch, first)
+}
+
+// createShortLinksBatch creates the links of the batch and sends their results.
+func (s *shortLinkServer) createShortLinksBatch(
+	stream grpc.BidiStreamingServer[pb.CreateShortLinkRequest, pb.CreateShortLinksResponse],
+	batch []domain.CreateAction,
+	first int,
+) error {
+	results, err := s.usecase.CreateMany(stream.Context(), batch)
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.CreateShortLinks", slog.Any("error", err))
+		}
+
+		return mapDomainError(err)
+	}
+
+	for i, result := range results {
+		response := &pb.CreateShortLinksResponse{Index: uint64(first + i)}
+		if result.Err == nil {
+			response.Link = toCreateShortLinkResponse(result.CreateResult)
+		} else {
+			if !isHandledDomainError(result.Err) {
+				s.logger.Error("GRPC.CreateShortLinks",
+					slog.Int("index", first+i),
+					slog.Any("error", result.Err),
+				)
+			}
+			itemStatus := status.Convert(mapDomainError(result.Err))
+			response.ErrorCode = uint32(itemStatus.Code())
+			response.ErrorMessage = itemStatus.Message()
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *shortLinkServer) UpdateShortLink(ctx context.Context, request *pb.UpdateShortLinkRequest) (*pb.UpdateShortLinkResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	updateAction := domain.UpdateAction{
+		Key:         request.GetLinkKey(),
+		Domain:      request.GetDomain(),
+		OriginalURL: request.Url,
+		Password:    request.Password,
+		ForwardPath: request.ForwardPath,
+	}
+	if request.GetExpiresAt() != nil {
+		expiresAt := request.GetExpiresAt().AsTime()
+		updateAction.ExpiresAt = &expiresAt
+	}
+	if request.GetTtl() != nil {
+		ttl := request.GetTtl().AsDuration()
+		updateAction.TTL = &ttl
+	}
+	if request.GetRemoveExpiration() {
+		if updateAction.ExpiresAt != nil || updateAction.TTL != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid expiration.")
+		}
+		updateAction.ExpiresAt = &time.Time{}
+	}
+	if request.MaxHits != nil {
+		maxHits := uint(request.GetMaxHits())
+		updateAction.MaxHits = &maxHits
+	}
+	if request.RedirectStatus != nil {
+		redirectStatus := int(request.GetRedirectStatus())
+		updateAction.RedirectStatus = &redirectStatus
+	}
+	if request.QueryForwarding != nil {
+		queryForwarding := domain.QueryForwarding(request.GetQueryForwarding())
+		updateAction.QueryForwarding = &queryForwarding
+	}
+
+	entity, err := s.usecase.Update(ctx, updateAction)
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.UpdateShortLink", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	return &pb.UpdateShortLinkResponse{Link: toShortLinkMessage(entity)}, nil
 }
 
 func (s *shortLinkServer) DeleteShortLink(ctx context.Context, request *pb.DeleteShortLinkRequest) (*emptypb.Empty, error) {
@@ -52,7 +178,7 @@ func (s *shortLinkServer) DeleteShortLink(ctx context.Context, request *pb.Delet
 		return nil, status.Error(codes.InvalidArgument, "Request is required.")
 	}
 
-	err := s.usecase.Delete(ctx, request.GetLinkKey())
+	err := s.usecase.Delete(ctx, domain.LinkRef{Domain: request.GetDomain(), Key: request.GetLinkKey()})
 	if err != nil {
 		if !isHandledDomainError(err) {
 			s.logger.Error("GRPC.DeleteShortLink", slog.Any("error", err))
@@ -64,12 +190,68 @@ func (s *shortLinkServer) DeleteShortLink(ctx context.Context, request *pb.Delet
 	return &emptypb.Empty{}, nil
 }
 
+func (s *shortLinkServer) RestoreShortLink(ctx context.Context, request *pb.RestoreShortLinkRequest) (*pb.RestoreShortLinkResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	entity, err := s.usecase.Restore(ctx, domain.LinkRef{Domain: request.GetDomain(), Key: request.GetLinkKey()})
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.RestoreShortLink", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	return &pb.RestoreShortLinkResponse{Link: toShortLinkMessage(entity)}, nil
+}
+
+func (s *shortLinkServer) DisableShortLink(ctx context.Context, request *pb.DisableShortLinkRequest) (*pb.DisableShortLinkResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	entity, err := s.usecase.Disable(ctx, domain.LinkRef{Domain: request.GetDomain(), Key: request.GetLinkKey()})
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.DisableShortLink", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	return &pb.DisableShortLinkResponse{Link: toShortLinkMessage(entity)}, nil
+}
+
+func (s *shortLinkServer) EnableShortLink(ctx context.Context, request *pb.EnableShortLinkRequest) (*pb.EnableShortLinkResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	entity, err := s.usecase.Enable(ctx, domain.LinkRef{Domain: request.GetDomain(), Key: request.GetLinkKey()})
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.EnableShortLink", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	return &pb.EnableShortLinkResponse{Link: toShortLinkMessage(entity)}, nil
+}
+
 func (s *shortLinkServer) ExpandShortLink(ctx context.Context, request *pb.ExpandShortLinkRequest) (*pb.ExpandShortLinkResponse, error) {
 	if request == nil {
 		return nil, status.Error(codes.InvalidArgument, "Request is required.")
 	}
 
-	entity, err := s.usecase.Expand(ctx, request.GetLinkKey())
+	attempt := domain.PasswordAttempt{
+		Password: request.GetPassword(),
+		ClientIP: clientIP(ctx, s.trustProxy),
+	}
+
+	entity, err := s.usecase.Expand(ctx, domain.LinkRef{Domain: request.GetDomain(), Key: request.GetLinkKey()}, attempt)
 	if err != nil {
 		if !isHandledDomainError(err) {
 			s.logger.Error("GRPC.ExpandShortLink", slog.Any("error", err))
@@ -78,7 +260,10 @@ func (s *shortLinkServer) ExpandShortLink(ctx context.Context, request *pb.Expan
 		return nil, mapDomainError(err)
 	}
 
-	return &pb.ExpandShortLinkResponse{Url: entity.OriginalURL}, nil
+	return &pb.ExpandShortLinkResponse{
+		Url:       entity.OriginalURL,
+		ExpiresAt: optionalTimestamp(entity.ExpiresAt),
+	}, nil
 }
 
 func (s *shortLinkServer) GetShortLinkStats(ctx context.Context, request *pb.GetShortLinkStatsRequest) (*pb.GetShortLinkStatsResponse, error) {
@@ -86,7 +271,31 @@ func (s *shortLinkServer) GetShortLinkStats(ctx context.Context, request *pb.Get
 		return nil, status.Error(codes.InvalidArgument, "Request is required.")
 	}
 
-	stats, err := s.usecase.Stats(ctx, request.GetLinkKey())
+	query := domain.StatsQuery{
+		Key:      request.GetLinkKey(),
+		Domain:   request.GetDomain(),
+		TopLimit: int(request.GetTopLimit()),
+	}
+	if request.GetFrom() != nil {
+		query.From = request.GetFrom().AsTime()
+	}
+	if request.GetTo() != nil {
+		query.To = request.GetTo().AsTime()
+	}
+
+	switch request.GetBucket() {
+	case pb.StatsBucket_STATS_BUCKET_UNSPECIFIED:
+	case pb.StatsBucket_STATS_BUCKET_HOUR:
+		query.Bucket = domain.StatsBucketHour
+	case pb.StatsBucket_STATS_BUCKET_DAY:
+		query.Bucket = domain.StatsBucketDay
+	case pb.StatsBucket_STATS_BUCKET_WEEK:
+		query.Bucket = domain.StatsBucketWeek
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Invalid stats query.")
+	}
+
+	stats, err := s.usecase.Stats(ctx, query)
 	if err != nil {
 		if !isHandledDomainError(err) {
 			s.logger.Error("GRPC.GetShortLinkStats", slog.Any("error", err))
@@ -95,8 +304,259 @@ func (s *shortLinkServer) GetShortLinkStats(ctx context.Context, request *pb.Get
 		return nil, mapDomainError(err)
 	}
 
+	timeline := make([]*pb.StatsPoint, 0, len(stats.Analytics.Timeline))
+	for _, p := range stats.Analytics.Timeline {
+		timeline = append(timeline, &pb.StatsPoint{
+			Start:     timestamppb.New(p.Start),
+			Clicks:    uint64(p.Clicks),
+			BotClicks: uint64(p.BotClicks),
+		})
+	}
+
 	return &pb.GetShortLinkStatsResponse{
-		Hits:      uint64(stats.Hits),
-		CreatedAt: timestamppb.New(stats.CreatedAt),
+		Hits:           uint64(stats.Hits),
+		BotHits:        uint64(stats.BotHits),
+		UniqueVisitors: uint64(stats.UniqueVisitors),
+		CreatedAt:      timestamppb.New(stats.CreatedAt),
+		MaxHits:        uint64(stats.MaxHits),
+		Timeline:       timeline,
+		TopReferrers:   toStatsCountMessages(stats.Analytics.TopReferrers),
+		TopCountries:   toStatsCountMessages(stats.Analytics.TopCountries),
+		TopUserAgents:  toStatsCountMessages(stats.Analytics.TopUserAgents),
+	}, nil
+}
+
+func (s *shortLinkServer) ListShortLinks(ctx context.Context, request *pb.ListShortLinksRequest) (*pb.ListShortLinksResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	query := domain.ListQuery{
+		Host:      request.GetHost(),
+		KeyPrefix: request.GetKeyPrefix(),
+		Ascending: request.GetAscending(),
+		Limit:     int(request.GetLimit()),
+		Cursor:    request.GetCursor(),
+		Deleted:   request.GetDeleted(),
+	}
+	if request.GetCreatedAfter() != nil {
+		query.CreatedAfter = request.GetCreatedAfter().AsTime()
+	}
+	if request.GetCreatedBefore() != nil {
+		query.CreatedBefore = request.GetCreatedBefore().AsTime()
+	}
+
+	switch request.GetSortBy() {
+	case pb.ListSortField_LIST_SORT_FIELD_UNSPECIFIED, pb.ListSortField_LIST_SORT_FIELD_CREATED_AT:
+		query.SortBy = domain.ListSortByCreatedAt
+	case pb.ListSortField_LIST_SORT_FIELD_HITS:
+		query.SortBy = domain.ListSortByHits
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Invalid list query.")
+	}
+
+	result, err := s.usecase.List(ctx, query)
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.ListShortLinks", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	links := make([]*pb.ShortLink, 0, len(result.Links))
+	for _, entity := range result.Links {
+		links = append(links, toShortLinkMessage(entity))
+	}
+
+	return &pb.ListShortLinksResponse{
+		Links:      links,
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
+func (s *shortLinkServer) ListClickEvents(ctx context.Context, request *pb.ListClickEventsRequest) (*pb.ListClickEventsResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	result, err := s.usecase.Clicks(ctx, domain.ClickQuery{
+		LinkKey:    request.GetLinkKey(),
+		LinkDomain: request.GetDomain(),
+		Limit:      int(request.GetLimit()),
+		Cursor:     request.GetCursor(),
+	})
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.ListClickEvents", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	events := make([]*pb.ClickEvent, 0, len(result.Events))
+	for _, event := range result.Events {
+		events = append(events, &pb.ClickEvent{
+			Timestamp:      timestamppb.New(event.Timestamp),
+			Referrer:       event.Referrer,
+			UserAgent:      event.UserAgent,
+			Ip:             event.IP,
+			AcceptLanguage: event.AcceptLanguage,
+			Country:        event.Country,
+			Bot:            event.Bot,
+		})
+	}
+
+	return &pb.ListClickEventsResponse{
+		Events:     events,
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
+func (s *shortLinkServer) ListShortLinkRevisions(ctx context.Context, request *pb.ListShortLinkRevisionsRequest) (*pb.ListShortLinkRevisionsResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	result, err := s.usecase.History(ctx, domain.HistoryQuery{
+		LinkKey:    request.GetLinkKey(),
+		LinkDomain: request.GetDomain(),
+		Limit:      int(request.GetLimit()),
+		Cursor:     request.GetCursor(),
+	})
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.ListShortLinkRevisions", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	revisions := make([]*pb.LinkRevision, 0, len(result.Revisions))
+	for _, revision := range result.Revisions {
+		changes := make([]*pb.FieldChange, 0, len(revision.Changes))
+		for _, change := range revision.Changes {
+			changes = append(changes, &pb.FieldChange{Field: change.Field, Old: change.Old, New: change.New})
+		}
+
+		revisions = append(revisions, &pb.LinkRevision{
+			Id:     revision.ID,
+			LinkId: revision.LinkID,
+			Action: string(revision.Action),
+			Actor: &pb.RevisionActor{
+				Owner:     revision.Actor.Owner,
+				Method:    string(revision.Actor.Method),
+				Transport: string(revision.Actor.Transport),
+			},
+			CreatedAt: timestamppb.New(revision.CreatedAt),
+			Changes:   changes,
+		})
+	}
+
+	return &pb.ListShortLinkRevisionsResponse{
+		Revisions:  revisions,
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
+func (s *shortLinkServer) GetShortLinkQRCode(ctx context.Context, request *pb.GetShortLinkQRCodeRequest) (*pb.GetShortLinkQRCodeResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "Request is required.")
+	}
+
+	query := domain.QRCodeQuery{
+		Key:        request.GetKey(),
+		Domain:     request.GetDomain(),
+		Format:     domain.QRCodeFormat(request.GetFormat()),
+		Size:       int(request.GetSize()),
+		Level:      request.GetLevel(),
+		Foreground: request.GetForeground(),
+		Background: request.GetBackground(),
+		Logo:       request.GetLogo(),
+	}
+	if request.Margin != nil {
+		margin := int(request.GetMargin())
+		query.Margin = &margin
+	}
+
+	qrCode, err := s.usecase.QRCode(ctx, query)
+	if err != nil {
+		if !isHandledDomainError(err) {
+			s.logger.Error("GRPC.GetShortLinkQRCode", slog.Any("error", err))
+		}
+
+		return nil, mapDomainError(err)
+	}
+
+	return &pb.GetShortLinkQRCodeResponse{
+		Image:       qrCode.Data,
+		ContentType: qrCode.ContentType,
 	}, nil
 }
+
+func newCreateAction(request *pb.CreateShortLinkRequest) domain.CreateAction {
+	createAction := domain.CreateAction{
+		OriginalURL:     request.GetUrl(),
+		Domain:          request.GetDomain(),
+		Alias:           request.GetAlias(),
+		MaxHits:         uint(request.GetMaxHits()),
+		Password:        request.GetPassword(),
+		RedirectStatus:  int(request.GetRedirectStatus()),
+		QueryForwarding: domain.QueryForwarding(request.GetQueryForwarding()),
+		ForwardPath:     request.GetForwardPath(),
+	}
+	if request.GetExpiresAt() != nil {
+		createAction.ExpiresAt = request.GetExpiresAt().AsTime()
+	}
+	if request.GetTtl() != nil {
+		createAction.TTL = request.GetTtl().AsDuration()
+	}
+
+	return createAction
+}
+
+func toCreateShortLinkResponse(result domain.CreateResult) *pb.CreateShortLinkResponse {
+	return &pb.CreateShortLinkResponse{
+		ShortUrl:  result.ShortURL,
+		Domain:    result.Domain,
+		Key:       result.Key,
+		ExpiresAt: optionalTimestamp(result.ExpiresAt),
+	}
+}
+
+func toStatsCountMessages(counts []domain.StatsCount) []*pb.StatsCount {
+	messages := make([]*pb.StatsCount, 0, len(counts))
+	for _, c := range counts {
+		messages = append(messages, &pb.StatsCount{Value: c.Value, Clicks: uint64(c.Clicks)})
+	}
+
+	return messages
+}
+
+func toShortLinkMessage(entity domain.ShortLink) *pb.ShortLink {
+	return &pb.ShortLink{
+		Domain:            entity.Domain,
+		Key:               entity.Key,
+		Url:               entity.OriginalURL,
+		Hits:              uint64(entity.Hits),
+		MaxHits:           uint64(entity.MaxHits),
+		CreatedAt:         timestamppb.New(entity.CreatedAt),
+		ExpiresAt:         optionalTimestamp(entity.ExpiresAt),
+		PasswordProtected: entity.IsProtected(),
+		RedirectStatus:    uint32(entity.RedirectStatus),
+		QueryForwarding:   string(entity.QueryForwarding),
+		ForwardPath:       entity.ForwardPath,
+		DeletedAt:         optionalTimestamp(entity.DeletedAt),
+		DeletedBy:         entity.DeletedBy,
+		Disabled:          entity.Disabled,
+	}
+}
+
+// optionalTimestamp converts the zero time to nil, so the field is left unset.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
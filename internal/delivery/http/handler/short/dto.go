@@ -1,21 +1,239 @@
 package short
 
-import "time"
+import (
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
 
 type shortenRequestDTO struct {
 	URL string `json:"url"`
+	// Short domain of the link, the primary domain when omitted
+	Domain    string     `json:"domain,omitempty"`
+	Alias     string     `json:"alias,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Go duration string, e.g. "72h" or "90m"
+	ExpiresIn string `json:"expires_in,omitempty"`
+	MaxHits   uint   `json:"max_hits,omitempty"`
+	// Password required to follow the link
+	Password string `json:"password,omitempty"`
+	// 301, 302, 307 or 308, the server default when omitted
+	RedirectStatus int `json:"redirect_status,omitempty"`
+	// keep, override or append, the query is not forwarded when omitted
+	QueryForwarding string `json:"query_forwarding,omitempty"`
+	ForwardPath     bool   `json:"forward_path,omitempty"`
 }
 
 type shortenResponseDTO struct {
 	ShortURL string `json:"short_url"`
-	Key      string `json:"key"`
+	// Omitted for the primary domain
+	Domain    string     `json:"domain,omitempty"`
+	Key       string     `json:"key"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func newShortenResponseDTO(out domain.CreateResult) shortenResponseDTO {
+	return shortenResponseDTO{
+		ShortURL:  out.ShortURL,
+		Domain:    out.Domain,
+		Key:       out.Key,
+		ExpiresAt: optionalTime(out.ExpiresAt),
+	}
+}
+
+type bulkShortenRequestDTO struct {
+	Items []shortenRequestDTO `json:"items"`
+}
+
+// bulkShortenItemDTO is the result of an item, the created link or its error.
+type bulkShortenItemDTO struct {
+	// Position of the item in the request
+	Index int `json:"index"`
+	// Set when the item is created
+	*shortenResponseDTO
+	Error *bulkItemErrorDTO `json:"error,omitempty"`
+}
+
+type bulkItemErrorDTO struct {
+	// Status the item would have been answered with on its own
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+type bulkShortenResponseDTO struct {
+	// In the order of the request
+	Items   []bulkShortenItemDTO `json:"items"`
+	Created int                  `json:"created"`
+	Failed  int                  `json:"failed"`
+}
+
+// updateRequestDTO holds the fields to change, omitted fields are left unchanged.
+type updateRequestDTO struct {
+	URL       *string    `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ExpiresIn *string    `json:"expires_in,omitempty"`
+	// Removes the expiry of the link
+	RemoveExpiration bool `json:"remove_expiration,omitempty"`
+	// Zero removes the hit limit
+	MaxHits *uint `json:"max_hits,omitempty"`
+	// An empty string removes the password
+	Password *string `json:"password,omitempty"`
+	// Zero resets the redirect status to the server default
+	RedirectStatus *int `json:"redirect_status,omitempty"`
+	// An empty string disables the query forwarding
+	QueryForwarding *string `json:"query_forwarding,omitempty"`
+	ForwardPath     *bool   `json:"forward_path,omitempty"`
+}
+
+type linkResponseDTO struct {
+	// Omitted for the primary domain
+	Domain    string     `json:"domain,omitempty"`
+	Key       string     `json:"key"`
+	URL       string     `json:"url"`
+	Hits      uint       `json:"hits"`
+	MaxHits   uint       `json:"max_hits,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// The password itself is never returned
+	PasswordProtected bool `json:"password_protected"`
+	// Omitted when the link uses the server default
+	RedirectStatus  int    `json:"redirect_status,omitempty"`
+	QueryForwarding string `json:"query_forwarding,omitempty"`
+	ForwardPath     bool   `json:"forward_path"`
+	Disabled        bool   `json:"disabled"`
+	// Set only for the links in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
+}
+
+type listResponseDTO struct {
+	Items      []linkResponseDTO `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+type clickEventDTO struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Referrer       string    `json:"referrer,omitempty"`
+	UserAgent      string    `json:"user_agent,omitempty"`
+	IP             string    `json:"ip,omitempty"`
+	AcceptLanguage string    `json:"accept_language,omitempty"`
+	Country        string    `json:"country,omitempty"`
+	Bot            bool      `json:"bot"`
+}
+
+type clicksResponseDTO struct {
+	Items      []clickEventDTO `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type fieldChangeDTO struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+type revisionActorDTO struct {
+	Owner     string `json:"owner,omitempty"`
+	Method    string `json:"method,omitempty"`
+	Transport string `json:"transport,omitempty"`
+}
+
+type revisionDTO struct {
+	ID        string           `json:"id"`
+	LinkID    string           `json:"link_id"`
+	Action    string           `json:"action"`
+	Actor     revisionActorDTO `json:"actor"`
+	CreatedAt time.Time        `json:"created_at"`
+	Changes   []fieldChangeDTO `json:"changes"`
+}
+
+type historyResponseDTO struct {
+	Items      []revisionDTO `json:"items"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+func newRevisionDTO(revision domain.LinkRevision) revisionDTO {
+	changes := make([]fieldChangeDTO, 0, len(revision.Changes))
+	for _, change := range revision.Changes {
+		changes = append(changes, fieldChangeDTO{Field: change.Field, Old: change.Old, New: change.New})
+	}
+
+	return revisionDTO{
+		ID:     revision.ID,
+		LinkID: revision.LinkID,
+		Action: string(revision.Action),
+		Actor: revisionActorDTO{
+			Owner:     revision.Actor.Owner,
+			Method:    string(revision.Actor.Method),
+			Transport: string(revision.Actor.Transport),
+		},
+		CreatedAt: revision.CreatedAt,
+		Changes:   changes,
+	}
 }
 
 type expandResponseDTO struct {
-	URL string `json:"url"`
+	URL       string     `json:"url"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 type statsResponseDTO struct {
-	Hits      uint      `json:"hits"`
-	CreatedAt time.Time `json:"created_at"`
+	Hits           uint      `json:"hits"`
+	BotHits        uint      `json:"bot_hits"`
+	UniqueVisitors uint      `json:"unique_visitors"`
+	MaxHits        uint      `json:"max_hits,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	// Click analytics, only present when a bucket is requested
+	Timeline      []statsPointDTO `json:"timeline,omitempty"`
+	TopReferrers  []statsCountDTO `json:"top_referrers,omitempty"`
+	TopCountries  []statsCountDTO `json:"top_countries,omitempty"`
+	TopUserAgents []statsCountDTO `json:"top_user_agents,omitempty"`
+}
+
+type statsPointDTO struct {
+	Start     time.Time `json:"start"`
+	Clicks    uint      `json:"clicks"`
+	BotClicks uint      `json:"bot_clicks"`
+}
+
+type statsCountDTO struct {
+	Value  string `json:"value"`
+	Clicks uint   `json:"clicks"`
+}
+
+func newLinkResponseDTO(ent domain.ShortLink) linkResponseDTO {
+	return linkResponseDTO{
+		Domain:            ent.Domain,
+		Key:               ent.Key,
+		URL:               ent.OriginalURL,
+		Hits:              ent.Hits,
+		MaxHits:           ent.MaxHits,
+		CreatedAt:         ent.CreatedAt,
+		ExpiresAt:         optionalTime(ent.ExpiresAt),
+		PasswordProtected: ent.IsProtected(),
+		RedirectStatus:    ent.RedirectStatus,
+		QueryForwarding:   string(ent.QueryForwarding),
+		ForwardPath:       ent.ForwardPath,
+		Disabled:          ent.Disabled,
+		DeletedAt:         optionalTime(ent.DeletedAt),
+		DeletedBy:         ent.DeletedBy,
+	}
+}
+
+func newStatsCountDTOs(counts []domain.StatsCount) []statsCountDTO {
+	dtos := make([]statsCountDTO, 0, len(counts))
+	for _, c := range counts {
+		dtos = append(dtos, statsCountDTO{Value: c.Value, Clicks: c.Clicks})
+	}
+
+	return dtos
+}
+
+// optionalTime converts the zero time to nil, so it is omitted from the JSON.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
 }
@@ -1,47 +1,217 @@
 package short
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/OsoianMarcel/url-shortener/internal/delivery/export"
 	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/httputil"
 	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/middleware"
 	"github.com/OsoianMarcel/url-shortener/internal/domain"
 )
 
 type handler struct {
-	logger                  *slog.Logger
-	usecase                 domain.ShortLinkUsecase
-	linkNotFoundRedirectURL string
+	logger               *slog.Logger
+	usecase              domain.ShortLinkUsecase
+	notFoundRedirects    NotFoundRedirects
+	disabledLinkResponse DisabledLinkResponse
+	trustProxy           bool
+	// serve the preview of a link at its root-level URL followed by "+"
+	linkPreview bool
+}
+
+// RateLimits are the limits of the routes which may be abused, a zero limit disables the limiting.
+// Each limit counts its own requests, the HTTP and gRPC routes of the same kind share it.
+type RateLimits struct {
+	Create domain.RateLimit
+	// A batch counts as one request
+	Bulk     domain.RateLimit
+	Redirect domain.RateLimit
+	Preview  domain.RateLimit
+	Expand   domain.RateLimit
+	// Limit of the stats and the click events
+	Stats domain.RateLimit
+	QR    domain.RateLimit
+}
+
+// DisabledLinkResponse is the response to the visitors of a disabled link.
+type DisabledLinkResponse string
+
+const (
+	// Redirect to the not found URL, like an unknown link
+	DisabledLinkNotFound DisabledLinkResponse = "not_found"
+	// Serve a page telling the link is paused
+	DisabledLinkPage DisabledLinkResponse = "page"
+	// Unavailable for legal reasons, e.g. while the destination is under review
+	DisabledLinkUnavailableForLegalReasons DisabledLinkResponse = "451"
+	DisabledLinkForbidden                  DisabledLinkResponse = "403"
+)
+
+// status returns the status code of the JSON responses about a disabled link.
+func (d DisabledLinkResponse) status() int {
+	switch d {
+	case DisabledLinkPage:
+		return http.StatusServiceUnavailable
+	case DisabledLinkUnavailableForLegalReasons:
+		return http.StatusUnavailableForLegalReasons
+	case DisabledLinkForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusNotFound
+	}
+}
+
+// NotFoundRedirects are the URLs the visitors of unknown links are redirected to.
+type NotFoundRedirects struct {
+	Default string
+	// URLs per lowercase short domain, the other hosts use the default one
+	ByDomain map[string]string
+}
+
+// url returns the redirect URL of the host the link is followed on.
+func (n NotFoundRedirects) url(host string) string {
+	if redirectURL, ok := n.ByDomain[strings.TrimSuffix(strings.ToLower(host), ".")]; ok {
+		return redirectURL
+	}
+
+	return n.Default
 }
 
 func RegisterHandler(
 	router *http.ServeMux,
 	logger *slog.Logger,
 	usecase domain.ShortLinkUsecase,
-	apiSecret string,
-	linkNotFoundRedirectURL string,
+	authUsecase domain.AuthUsecase,
+	rateLimiter domain.RateLimiter,
+	rateLimits RateLimits,
+	notFoundRedirects NotFoundRedirects,
+	trustProxy bool,
+	linkPreview bool,
+	disabledLinkResponse DisabledLinkResponse,
 ) {
 	h := &handler{
-		logger:                  logger,
-		usecase:                 usecase,
-		linkNotFoundRedirectURL: linkNotFoundRedirectURL,
+		logger:               logger,
+		usecase:              usecase,
+		notFoundRedirects:    notFoundRedirects,
+		trustProxy:           trustProxy,
+		linkPreview:          linkPreview,
+		disabledLinkResponse: disabledLinkResponse,
 	}
 
 	router.Handle("POST /api/shortener", middleware.Chain(
 		h.shorten(),
-		middleware.AuthenticationMiddleware(apiSecret, logger),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksCreate, logger),
+		// after the authentication, so the links are created within the limit of each key
+		middleware.RateLimitMiddleware(rateLimiter, "create", rateLimits.Create, trustProxy, logger),
+	))
+	router.Handle("POST /api/shortener/bulk", middleware.Chain(
+		h.shortenBulk(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksCreate, logger),
+		middleware.RateLimitMiddleware(rateLimiter, "bulk", rateLimits.Bulk, trustProxy, logger),
+	))
+	router.Handle("GET /api/shortener", middleware.Chain(
+		h.list(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksRead, logger),
+	))
+	router.Handle("GET /api/shortener/export", middleware.Chain(
+		h.export(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksRead, logger),
+	))
+	router.Handle("PATCH /api/shortener/{linkKey}", middleware.Chain(
+		h.update(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksUpdate, logger),
 	))
 	router.Handle("DELETE /api/shortener/{linkKey}", middleware.Chain(
 		h.delete(),
-		middleware.AuthenticationMiddleware(apiSecret, logger),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksDelete, logger),
+	))
+	router.Handle("POST /api/shortener/{linkKey}/restore", middleware.Chain(
+		h.restore(),
+		// restoring undoes a deletion, so it needs the same scope
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksDelete, logger),
+	))
+	router.Handle("POST /api/shortener/{linkKey}/disable", middleware.Chain(
+		h.disable(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksUpdate, logger),
+	))
+	router.Handle("POST /api/shortener/{linkKey}/enable", middleware.Chain(
+		h.enable(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksUpdate, logger),
+	))
+	router.Handle("GET /api/shortener/{linkKey}/redirect", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	// the password form of a protected link is posted to its redirect URL
+	router.Handle("POST /api/shortener/{linkKey}/redirect", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	// the path following the redirect URL is forwarded to the links with path forwarding
+	router.Handle("GET /api/shortener/{linkKey}/redirect/{pathSuffix...}", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	router.Handle("POST /api/shortener/{linkKey}/redirect/{pathSuffix...}", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	router.Handle("GET /api/shortener/{linkKey}/expand", middleware.Chain(
+		h.expand(),
+		middleware.RateLimitMiddleware(rateLimiter, "expand", rateLimits.Expand, trustProxy, logger),
 	))
-	router.Handle("GET /api/shortener/{linkKey}/redirect", h.redirect())
-	router.Handle("GET /api/shortener/{linkKey}/expand", h.expand())
 	router.Handle("GET /api/shortener/{linkKey}/stats", middleware.Chain(
 		h.stats(),
-		middleware.AuthenticationMiddleware(apiSecret, logger),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksStats, logger),
+		middleware.RateLimitMiddleware(rateLimiter, "stats", rateLimits.Stats, trustProxy, logger),
 	))
+	router.Handle("GET /api/shortener/{linkKey}/clicks", middleware.Chain(
+		h.clicks(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksStats, logger),
+		middleware.RateLimitMiddleware(rateLimiter, "stats", rateLimits.Stats, trustProxy, logger),
+	))
+	router.Handle("GET /api/shortener/{linkKey}/history", middleware.Chain(
+		h.history(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksRead, logger),
+	))
+	router.Handle("GET /api/shortener/{linkKey}/qr", middleware.Chain(
+		h.qrCode(),
+		middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksRead, logger),
+		middleware.RateLimitMiddleware(rateLimiter, "qr", rateLimits.QR, trustProxy, logger),
+	))
+
+	// root-level short URLs, the routes with a fixed path like /health take precedence over them
+	// and the aliases colliding with those routes are reserved
+	router.Handle("GET /{linkKey}", h.rootRedirect(
+		middleware.Chain(
+			h.redirect(),
+			middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+		),
+		middleware.Chain(
+			h.preview(),
+			middleware.RateLimitMiddleware(rateLimiter, "preview", rateLimits.Preview, trustProxy, logger),
+		),
+	))
+	router.Handle("POST /{linkKey}", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	router.Handle("GET /{linkKey}/{pathSuffix...}", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	router.Handle("POST /{linkKey}/{pathSuffix...}", middleware.Chain(
+		h.redirect(),
+		middleware.RateLimitMiddleware(rateLimiter, "redirect", rateLimits.Redirect, trustProxy, logger),
+	))
+	// an unknown API route is not the path of the "api" link
+	router.Handle("GET /api/", h.notFound())
+	router.Handle("POST /api/", h.notFound())
 }
 
 func (h *handler) shorten() http.Handler {
@@ -54,16 +224,389 @@ func (h *handler) shorten() http.Handler {
 			return
 		}
 
-		out, err := h.usecase.Create(r.Context(), domain.CreateAction{
-			OriginalURL: requestDTO.URL,
+		out, err := h.create(r.Context(), requestDTO)
+		if err != nil {
+			if status, message, ok := createErrorResponse(err); ok {
+				responder.Error(status, message)
+				return
+			}
+
+			h.logger.Error(
+				"Handler.shorten",
+				slog.Any("error", err),
+			)
+			responder.ServerError()
+			return
+		}
+
+		responder.Created(newShortenResponseDTO(out))
+	})
+}
+
+func (h *handler) create(ctx context.Context, requestDTO shortenRequestDTO) (domain.CreateResult, error) {
+	createAction, err := newCreateAction(requestDTO)
+	if err != nil {
+		return domain.CreateResult{}, err
+	}
+
+	return h.usecase.Create(ctx, createAction)
+}
+
+const (
+	// maxBulkBodySize limits the body of a bulk creation, which holds up to domain.MaxBulkCreateItems links.
+	maxBulkBodySize = 16 << 20
+	// bulkTimeout replaces the read and write timeouts of the server for a bulk creation,
+	// reading a body of maxBulkBodySize and hashing the passwords of a full batch take longer than a single request.
+	bulkTimeout = 2 * time.Minute
+)
+
+func (h *handler) shortenBulk() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBulkBodySize)
+		controller := http.NewResponseController(w)
+		deadline := time.Now().Add(bulkTimeout)
+		if err := controller.SetReadDeadline(deadline); err != nil {
+			h.logger.Warn(
+				"unable to extend the read deadline of the bulk creation",
+				slog.Any("error", err),
+			)
+		}
+		if err := controller.SetWriteDeadline(deadline); err != nil {
+			h.logger.Warn(
+				"unable to extend the write deadline of the bulk creation",
+				slog.Any("error", err),
+			)
+		}
+		requestDTO, err := httputil.JsonBodyDecode[bulkShortenRequestDTO](r)
+		if err != nil {
+			responder.InvalidJsonError()
+			return
+		}
+		if len(requestDTO.Items) == 0 || len(requestDTO.Items) > domain.MaxBulkCreateItems {
+			responder.BadRequest(fmt.Sprintf("The batch must contain 1 to %d items.", domain.MaxBulkCreateItems))
+			return
+		}
+
+		resDTO := bulkShortenResponseDTO{
+			Items: make([]bulkShortenItemDTO, len(requestDTO.Items)),
+		}
+		// the items failing before the usecase, like an invalid duration, keep their position
+		createActions := make([]domain.CreateAction, 0, len(requestDTO.Items))
+		indexes := make([]int, 0, len(requestDTO.Items))
+		for i, itemDTO := range requestDTO.Items {
+			createAction, err := newCreateAction(itemDTO)
+			if err != nil {
+				resDTO.Items[i] = h.newBulkShortenItemDTO(i, domain.BulkCreateResult{Err: err})
+				continue
+			}
+
+			createActions = append(createActions, createAction)
+			indexes = append(indexes, i)
+		}
+
+		if len(createActions) > 0 {
+			results, err := h.usecase.CreateMany(r.Context(), createActions)
+			if err != nil {
+				h.logger.Error(
+					"Handler.shortenBulk",
+					slog.Any("error", err),
+				)
+				responder.ServerError()
+				return
+			}
+
+			for j, result := range results {
+				resDTO.Items[indexes[j]] = h.newBulkShortenItemDTO(indexes[j], result)
+			}
+		}
+
+		for _, itemDTO := range resDTO.Items {
+			if itemDTO.Error == nil {
+				resDTO.Created++
+			} else {
+				resDTO.Failed++
+			}
+		}
+
+		responder.OK(resDTO)
+	})
+}
+
+func (h *handler) newBulkShortenItemDTO(index int, result domain.BulkCreateResult) bulkShortenItemDTO {
+	if result.Err == nil {
+		resDTO := newShortenResponseDTO(result.CreateResult)
+		return bulkShortenItemDTO{Index: index, shortenResponseDTO: &resDTO}
+	}
+
+	status, message, ok := createErrorResponse(result.Err)
+	if !ok {
+		h.logger.Error(
+			"Handler.shortenBulk",
+			slog.Int("index", index),
+			slog.Any("error", result.Err),
+		)
+		status, message = http.StatusInternalServerError, "Internal server error."
+	}
+
+	return bulkShortenItemDTO{Index: index, Error: &bulkItemErrorDTO{Status: status, Message: message}}
+}
+
+// newCreateAction maps the request of a link to the domain action.
+func newCreateAction(requestDTO shortenRequestDTO) (domain.CreateAction, error) {
+	createAction := domain.CreateAction{
+		OriginalURL:     requestDTO.URL,
+		Domain:          requestDTO.Domain,
+		Alias:           requestDTO.Alias,
+		MaxHits:         requestDTO.MaxHits,
+		Password:        requestDTO.Password,
+		RedirectStatus:  requestDTO.RedirectStatus,
+		QueryForwarding: domain.QueryForwarding(requestDTO.QueryForwarding),
+		ForwardPath:     requestDTO.ForwardPath,
+	}
+	if requestDTO.ExpiresAt != nil {
+		createAction.ExpiresAt = *requestDTO.ExpiresAt
+	}
+	if requestDTO.ExpiresIn != "" {
+		ttl, err := time.ParseDuration(requestDTO.ExpiresIn)
+		if err != nil {
+			return domain.CreateAction{}, domain.ErrInvalidExpiration
+		}
+		createAction.TTL = ttl
+	}
+
+	return createAction, nil
+}
+
+// createErrorResponse returns the status and the message of the errors of a link creation caused by the client.
+func createErrorResponse(err error) (int, string, bool) {
+	switch err {
+	case domain.ErrInvalidURL:
+		return http.StatusBadRequest, "Invalid URL.", true
+	case domain.ErrURLTooLong:
+		return http.StatusBadRequest, "The URL is too long.", true
+	case domain.ErrDomainNotAllowed:
+		return http.StatusBadRequest, "The URL domain is not allowed.", true
+	case domain.ErrPrivateAddress:
+		return http.StatusBadRequest, "The URL points to a private network address.", true
+	case domain.ErrShortenerURL:
+		return http.StatusBadRequest, "The URL points to a URL shortener.", true
+	case domain.ErrInvalidAlias:
+		return http.StatusBadRequest, "Invalid alias.", true
+	case domain.ErrReservedAlias:
+		return http.StatusBadRequest, "The alias is reserved.", true
+	case domain.ErrShortLinkKeyExists:
+		return http.StatusConflict, "The alias is already taken.", true
+	case domain.ErrInvalidExpiration:
+		return http.StatusBadRequest, "Invalid expiration.", true
+	case domain.ErrInvalidLinkPassword:
+		return http.StatusBadRequest, "Invalid password.", true
+	case domain.ErrInvalidRedirectStatus:
+		return http.StatusBadRequest, "Invalid redirect status.", true
+	case domain.ErrInvalidQueryForwarding:
+		return http.StatusBadRequest, "Invalid query forwarding.", true
+	case domain.ErrUnknownDomain:
+		return http.StatusBadRequest, "Unknown domain.", true
+	default:
+		return 0, "", false
+	}
+}
+
+func (h *handler) list() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		query, err := parseListQuery(r.URL.Query())
+		if err != nil {
+			responder.BadRequest("Invalid query parameters.")
+			return
+		}
+
+		result, err := h.usecase.List(r.Context(), query)
+		if err != nil {
+			switch err {
+			case domain.ErrInvalidListQuery:
+				responder.BadRequest("Invalid query parameters.")
+			case domain.ErrInvalidCursor:
+				responder.BadRequest("Invalid cursor.")
+			default:
+				h.logger.Error(
+					"Handler.list",
+					slog.Any("error", err),
+				)
+				responder.ServerError()
+			}
+			return
+		}
+
+		resDTO := listResponseDTO{
+			Items:      make([]linkResponseDTO, 0, len(result.Links)),
+			NextCursor: result.NextCursor,
+		}
+		for _, ent := range result.Links {
+			resDTO.Items = append(resDTO.Items, newLinkResponseDTO(ent))
+		}
+
+		responder.OK(resDTO)
+	})
+}
+
+// exportFlushInterval is the number of exported links written between two flushes of the response.
+const exportFlushInterval = 100
+
+func (h *handler) export() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		format, err := export.ParseFormat(r.URL.Query().Get("format"))
+		if err != nil {
+			responder.BadRequest("Invalid query parameters.")
+			return
+		}
+		query, err := parseExportQuery(r.URL.Query())
+		if err != nil {
+			responder.BadRequest("Invalid query parameters.")
+			return
+		}
+
+		controller := http.NewResponseController(w)
+		// the export of a large collection outlives the write timeout of the server
+		if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+			h.logger.Warn(
+				"unable to extend the write deadline of the export",
+				slog.Any("error", err),
+			)
+		}
+
+		// the headers are sent with the first link, so the invalid queries are still answered with an error
+		started := false
+		start := func() {
+			w.Header().Set("Content-Type", format.ContentType())
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="links.%s"`, format))
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		writer := export.NewWriter(w, format)
+		written := 0
+		err = h.usecase.Export(r.Context(), query, func(link domain.ShortLink) error {
+			if !started {
+				start()
+			}
+			if err := writer.Write(link); err != nil {
+				return err
+			}
+
+			written++
+			if written%exportFlushInterval != 0 {
+				return nil
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			return controller.Flush()
 		})
+		if err == nil {
+			if !started {
+				start()
+			}
+			err = writer.Flush()
+		}
+		if err != nil {
+			if !started {
+				switch err {
+				case domain.ErrInvalidExportQuery:
+					responder.BadRequest("Invalid query parameters.")
+				case domain.ErrUnknownDomain:
+					responder.BadRequest("Unknown domain.")
+				default:
+					h.logger.Error(
+						"Handler.export",
+						slog.Any("error", err),
+					)
+					responder.ServerError()
+				}
+				return
+			}
+
+			h.logger.Error(
+				"Handler.export",
+				slog.Int("written", written),
+				slog.Any("error", err),
+			)
+			// the status is already sent, the response is cut so the client does not take the export as complete
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+func (h *handler) update() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		requestDTO, err := httputil.JsonBodyDecode[updateRequestDTO](r)
+		if err != nil {
+			responder.InvalidJsonError()
+			return
+		}
+
+		updateAction := domain.UpdateAction{
+			Key:             r.PathValue("linkKey"),
+			Domain:          r.URL.Query().Get("domain"),
+			OriginalURL:     requestDTO.URL,
+			ExpiresAt:       requestDTO.ExpiresAt,
+			MaxHits:         requestDTO.MaxHits,
+			Password:        requestDTO.Password,
+			RedirectStatus:  requestDTO.RedirectStatus,
+			QueryForwarding: (*domain.QueryForwarding)(requestDTO.QueryForwarding),
+			ForwardPath:     requestDTO.ForwardPath,
+		}
+		if requestDTO.ExpiresIn != nil {
+			ttl, err := time.ParseDuration(*requestDTO.ExpiresIn)
+			if err != nil {
+				responder.BadRequest("Invalid expiration.")
+				return
+			}
+			updateAction.TTL = &ttl
+		}
+		if requestDTO.RemoveExpiration {
+			if updateAction.ExpiresAt != nil || updateAction.TTL != nil {
+				responder.BadRequest("Invalid expiration.")
+				return
+			}
+			updateAction.ExpiresAt = &time.Time{}
+		}
+
+		ent, err := h.usecase.Update(r.Context(), updateAction)
 		if err != nil {
 			switch err {
+			case domain.ErrShortLinkNotFound:
+				responder.NotFound("Link not found.")
 			case domain.ErrInvalidURL:
 				responder.BadRequest("Invalid URL.")
+			case domain.ErrURLTooLong:
+				responder.BadRequest("The URL is too long.")
+			case domain.ErrDomainNotAllowed:
+				responder.BadRequest("The URL domain is not allowed.")
+			case domain.ErrPrivateAddress:
+				responder.BadRequest("The URL points to a private network address.")
+			case domain.ErrShortenerURL:
+				responder.BadRequest("The URL points to a URL shortener.")
+			case domain.ErrInvalidExpiration:
+				responder.BadRequest("Invalid expiration.")
+			case domain.ErrInvalidLinkPassword:
+				responder.BadRequest("Invalid password.")
+			case domain.ErrInvalidRedirectStatus:
+				responder.BadRequest("Invalid redirect status.")
+			case domain.ErrInvalidQueryForwarding:
+				responder.BadRequest("Invalid query forwarding.")
+			case domain.ErrUnknownDomain:
+				responder.BadRequest("Unknown domain.")
 			default:
 				h.logger.Error(
-					"Handler.shorten",
+					"Handler.update",
 					slog.Any("error", err),
 				)
 				responder.ServerError()
@@ -71,11 +614,7 @@ func (h *handler) shorten() http.Handler {
 			return
 		}
 
-		resDTO := shortenResponseDTO{
-			ShortURL: out.ShortURL,
-			Key:      out.Key,
-		}
-		responder.Created(resDTO)
+		responder.OK(newLinkResponseDTO(ent))
 	})
 }
 
@@ -83,12 +622,16 @@ func (h *handler) delete() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		responder := httputil.NewJsonResponder(w, h.logger)
 
-		err := h.usecase.Delete(r.Context(), r.PathValue("linkKey"))
+		err := h.usecase.Delete(r.Context(), linkRef(r))
 		if err != nil {
 			if err == domain.ErrShortLinkNotFound {
 				responder.NotFound("Link not found.")
 				return
 			}
+			if err == domain.ErrUnknownDomain {
+				responder.BadRequest("Unknown domain.")
+				return
+			}
 
 			h.logger.Error(
 				"Handler.delete",
@@ -101,12 +644,140 @@ func (h *handler) delete() http.Handler {
 	})
 }
 
-func (h *handler) redirect() http.Handler {
+func (h *handler) restore() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		ent, err := h.usecase.Restore(r.Context(), linkRef(r))
+		if err != nil {
+			if err == domain.ErrShortLinkNotFound {
+				responder.NotFound("Link not found in the trash.")
+				return
+			}
+			if err == domain.ErrUnknownDomain {
+				responder.BadRequest("Unknown domain.")
+				return
+			}
+
+			h.logger.Error(
+				"Handler.restore",
+				slog.Any("error", err),
+			)
+			responder.ServerError()
+			return
+		}
+
+		responder.OK(newLinkResponseDTO(ent))
+	})
+}
+
+func (h *handler) disable() http.Handler {
+	return h.setDisabled("Handler.disable", h.usecase.Disable)
+}
+
+func (h *handler) enable() http.Handler {
+	return h.setDisabled("Handler.enable", h.usecase.Enable)
+}
+
+// setDisabled serves the disabling or the enabling of a link, both respond with the changed link.
+func (h *handler) setDisabled(name string, change func(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		originalURL, err := h.usecase.OriginalURL(r.Context(), r.PathValue("linkKey"))
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		ent, err := change(r.Context(), linkRef(r))
 		if err != nil {
 			if err == domain.ErrShortLinkNotFound {
-				http.Redirect(w, r, h.linkNotFoundRedirectURL, http.StatusFound)
+				responder.NotFound("Link not found.")
+				return
+			}
+			if err == domain.ErrUnknownDomain {
+				responder.BadRequest("Unknown domain.")
+				return
+			}
+
+			h.logger.Error(
+				name,
+				slog.Any("error", err),
+			)
+			responder.ServerError()
+			return
+		}
+
+		responder.OK(newLinkResponseDTO(ent))
+	})
+}
+
+// disabledLink responds to the visitor of a disabled link as configured.
+func (h *handler) disabledLink(w http.ResponseWriter, r *http.Request) {
+	switch h.disabledLinkResponse {
+	case DisabledLinkPage:
+		renderPausedPage(w, h.logger)
+	case DisabledLinkUnavailableForLegalReasons, DisabledLinkForbidden:
+		w.Header().Set("Cache-Control", "no-store")
+		httputil.NewJsonResponder(w, h.logger).Error(h.disabledLinkResponse.status(), "Link disabled.")
+	default:
+		http.Redirect(w, r, h.notFoundRedirects.url(r.Host), http.StatusFound)
+	}
+}
+
+func (h *handler) redirect() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		visit := domain.Visit{
+			Referrer:       r.Referer(),
+			UserAgent:      r.UserAgent(),
+			IP:             httputil.GetRealIP(r, h.trustProxy),
+			AcceptLanguage: r.Header.Get("Accept-Language"),
+			Country:        httputil.GetCountry(r, h.trustProxy),
+			Purpose:        httputil.GetPurpose(r),
+		}
+
+		request := domain.RedirectRequest{
+			Domain:   r.Host,
+			Key:      r.PathValue("linkKey"),
+			RawQuery: r.URL.RawQuery,
+			Visit:    visit,
+		}
+		if pathSuffix := r.PathValue("pathSuffix"); pathSuffix != "" {
+			request.PathSuffix = "/" + pathSuffix
+		}
+
+		redirect, err := h.usecase.Redirect(r.Context(), request)
+		// the form is read only for a protected link, a POST to another link is redirected as it is
+		if err == domain.ErrPasswordRequired && r.Method == http.MethodPost {
+			r.Body = http.MaxBytesReader(w, r.Body, maxPasswordFormSize)
+			if err := r.ParseForm(); err != nil {
+				renderPasswordForm(w, h.logger, http.StatusBadRequest, "Invalid form.")
+				return
+			}
+			if request.Password = r.PostForm.Get("password"); request.Password != "" {
+				redirect, err = h.usecase.Redirect(r.Context(), request)
+			}
+		}
+		if err != nil {
+			if err == domain.ErrShortLinkNotFound || err == domain.ErrShortLinkExhausted {
+				http.Redirect(w, r, h.notFoundRedirects.url(r.Host), http.StatusFound)
+				return
+			}
+			if err == domain.ErrShortLinkDisabled {
+				h.disabledLink(w, r)
+				return
+			}
+
+			switch err {
+			case domain.ErrPasswordRequired:
+				renderPasswordForm(w, h.logger, http.StatusOK, "")
+				return
+			case domain.ErrWrongPassword:
+				renderPasswordForm(w, h.logger, http.StatusUnauthorized, "Wrong password.")
+				return
+			case domain.ErrTooManyPasswordAttempts:
+				renderPasswordForm(w, h.logger, http.StatusTooManyRequests, "Too many attempts, try again later.")
+				return
+			}
+
+			responder := httputil.NewJsonResponder(w, h.logger)
+			if err == domain.ErrShortLinkExpired {
+				responder.Gone("Link expired.")
 				return
 			}
 
@@ -114,24 +785,139 @@ func (h *handler) redirect() http.Handler {
 				"Handler.redirect",
 				slog.Any("error", err),
 			)
+			responder.ServerError()
+			return
+		}
+
+		// permanent redirects are cached by the browsers, the hits of a cached redirect are not counted
+		if redirect.MaxAge > 0 {
+			w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(redirect.MaxAge.Seconds())))
+		} else {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+
+		// a posted password form is followed by a GET of the original URL,
+		// a 307 or 308 would post the password to the destination
+		if request.Password != "" {
+			http.Redirect(w, r, redirect.URL, http.StatusSeeOther)
+			return
+		}
+
+		http.Redirect(w, r, redirect.URL, redirect.StatusCode)
+	})
+}
+
+// rootRedirect serves the root-level URL of a link, or its preview when the key is followed by "+".
+func (h *handler) rootRedirect(redirect http.Handler, preview http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// "+" is not allowed in the keys, so it cannot be mistaken for a part of the key
+		if key, ok := strings.CutSuffix(r.PathValue("linkKey"), "+"); ok && h.linkPreview {
+			r.SetPathValue("linkKey", key)
+			preview.ServeHTTP(w, r)
+			return
+		}
+
+		redirect.ServeHTTP(w, r)
+	})
+}
+
+// preview shows where a link leads without following it, so the visit is not recorded.
+func (h *handler) preview() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("linkKey")
+		attempt := domain.PasswordAttempt{ClientIP: httputil.GetRealIP(r, h.trustProxy)}
+
+		// the preview is served on the short domain of the link, an unknown host is the primary domain
+		ent, err := h.usecase.Preview(r.Context(), domain.LinkRef{Domain: r.Host, Key: key}, attempt)
+		if err != nil {
+			if err == domain.ErrShortLinkNotFound || err == domain.ErrShortLinkExhausted {
+				http.Redirect(w, r, h.notFoundRedirects.url(r.Host), http.StatusFound)
+				return
+			}
+			if err == domain.ErrShortLinkDisabled {
+				h.disabledLink(w, r)
+				return
+			}
+			if err == domain.ErrPasswordRequired {
+				renderPreviewPage(w, h.logger, previewPageData{Key: key, Protected: true})
+				return
+			}
+
 			responder := httputil.NewJsonResponder(w, h.logger)
+			if err == domain.ErrShortLinkExpired {
+				responder.Gone("Link expired.")
+				return
+			}
+
+			h.logger.Error(
+				"Handler.preview",
+				slog.Any("error", err),
+			)
 			responder.ServerError()
 			return
 		}
 
-		http.Redirect(w, r, originalURL, http.StatusFound)
+		renderPreviewPage(w, h.logger, previewPageData{
+			Key:         key,
+			Destination: ent.OriginalURL,
+			ExpiresAt:   ent.ExpiresAt,
+		})
+	})
+}
+
+func (h *handler) notFound() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httputil.NewJsonResponder(w, h.logger).NotFound("Not found.")
 	})
 }
 
 func (h *handler) expand() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		responder := httputil.NewJsonResponder(w, h.logger)
-		shortUrlEntity, err := h.usecase.Expand(r.Context(), r.PathValue("linkKey"))
+		attempt := domain.PasswordAttempt{
+			Password: r.Header.Get("X-Link-Password"),
+			ClientIP: httputil.GetRealIP(r, h.trustProxy),
+		}
+
+		shortUrlEntity, err := h.usecase.Expand(r.Context(), linkRef(r), attempt)
 		if err != nil {
 			if err == domain.ErrShortLinkNotFound {
 				responder.NotFound("Link not found.")
 				return
 			}
+			if err == domain.ErrUnknownDomain {
+				responder.BadRequest("Unknown domain.")
+				return
+			}
+			if err == domain.ErrShortLinkExpired {
+				responder.Gone("Link expired.")
+				return
+			}
+			if err == domain.ErrShortLinkExhausted {
+				responder.Gone("Link hit limit reached.")
+				return
+			}
+			if err == domain.ErrShortLinkDisabled {
+				// an unknown link is reported as not found, so is a disabled link without a dedicated response
+				if h.disabledLinkResponse.status() == http.StatusNotFound {
+					responder.NotFound("Link not found.")
+					return
+				}
+				responder.Error(h.disabledLinkResponse.status(), "Link disabled.")
+				return
+			}
+			if err == domain.ErrPasswordRequired {
+				responder.Unauthorized("The link is password protected.")
+				return
+			}
+			if err == domain.ErrWrongPassword {
+				responder.Forbidden("Wrong password.")
+				return
+			}
+			if err == domain.ErrTooManyPasswordAttempts {
+				responder.TooManyRequests("Too many password attempts.")
+				return
+			}
 
 			h.logger.Error(
 				"Handler.expand",
@@ -142,7 +928,8 @@ func (h *handler) expand() http.Handler {
 		}
 
 		resDTO := expandResponseDTO{
-			URL: shortUrlEntity.OriginalURL,
+			URL:       shortUrlEntity.OriginalURL,
+			ExpiresAt: optionalTime(shortUrlEntity.ExpiresAt),
 		}
 
 		responder.OK(resDTO)
@@ -152,12 +939,26 @@ func (h *handler) expand() http.Handler {
 func (h *handler) stats() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		responder := httputil.NewJsonResponder(w, h.logger)
-		stats, err := h.usecase.Stats(r.Context(), r.PathValue("linkKey"))
+		query, err := parseStatsQuery(r.PathValue("linkKey"), r.URL.Query())
+		if err != nil {
+			responder.BadRequest("Invalid query parameters.")
+			return
+		}
+
+		stats, err := h.usecase.Stats(r.Context(), query)
 		if err != nil {
 			if err == domain.ErrShortLinkNotFound {
 				responder.NotFound("Link not found.")
 				return
 			}
+			if err == domain.ErrInvalidStatsQuery {
+				responder.BadRequest("Invalid query parameters.")
+				return
+			}
+			if err == domain.ErrUnknownDomain {
+				responder.BadRequest("Unknown domain.")
+				return
+			}
 
 			h.logger.Error(
 				"Handler.stats",
@@ -168,10 +969,196 @@ func (h *handler) stats() http.Handler {
 		}
 
 		resDTO := statsResponseDTO{
-			Hits:      stats.Hits,
-			CreatedAt: stats.CreatedAt,
+			Hits:           stats.Hits,
+			BotHits:        stats.BotHits,
+			UniqueVisitors: stats.UniqueVisitors,
+			MaxHits:        stats.MaxHits,
+			CreatedAt:      stats.CreatedAt,
+		}
+		if query.Bucket != "" {
+			resDTO.Timeline = make([]statsPointDTO, 0, len(stats.Analytics.Timeline))
+			for _, p := range stats.Analytics.Timeline {
+				resDTO.Timeline = append(resDTO.Timeline, statsPointDTO{
+					Start:     p.Start,
+					Clicks:    p.Clicks,
+					BotClicks: p.BotClicks,
+				})
+			}
+			resDTO.TopReferrers = newStatsCountDTOs(stats.Analytics.TopReferrers)
+			resDTO.TopCountries = newStatsCountDTOs(stats.Analytics.TopCountries)
+			resDTO.TopUserAgents = newStatsCountDTOs(stats.Analytics.TopUserAgents)
 		}
 
 		responder.OK(resDTO)
 	})
 }
+
+func (h *handler) qrCode() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+		query, err := parseQRCodeQuery(r.PathValue("linkKey"), r.URL.Query())
+		if err != nil {
+			responder.BadRequest("Invalid query parameters.")
+			return
+		}
+
+		qrCode, err := h.usecase.QRCode(r.Context(), query)
+		if err != nil {
+			if err == domain.ErrShortLinkNotFound {
+				responder.NotFound("Link not found.")
+				return
+			}
+			if err == domain.ErrShortLinkExpired {
+				responder.Gone("Link expired.")
+				return
+			}
+			if err == domain.ErrInvalidQRCodeQuery {
+				responder.BadRequest("Invalid query parameters.")
+				return
+			}
+			if err == domain.ErrQRCodeLogoNotConfigured {
+				responder.BadRequest("No QR code logo is configured.")
+				return
+			}
+			if err == domain.ErrUnknownDomain {
+				responder.BadRequest("Unknown domain.")
+				return
+			}
+
+			h.logger.Error(
+				"Handler.qrCode",
+				slog.Any("error", err),
+			)
+			responder.ServerError()
+			return
+		}
+
+		w.Header().Set("Content-Type", qrCode.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(qrCode.Data)))
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+		// the SVG opened directly must not run anything
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src data:; style-src 'unsafe-inline'")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(qrCode.Data); err != nil {
+			h.logger.Warn(
+				"failed to write the qr code",
+				slog.Any("error", err),
+			)
+		}
+	})
+}
+
+func (h *handler) clicks() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		query := domain.ClickQuery{
+			LinkKey:    r.PathValue("linkKey"),
+			LinkDomain: r.URL.Query().Get("domain"),
+			Cursor:     r.URL.Query().Get("cursor"),
+		}
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			var err error
+			if query.Limit, err = strconv.Atoi(limit); err != nil {
+				responder.BadRequest("Invalid query parameters.")
+				return
+			}
+		}
+
+		result, err := h.usecase.Clicks(r.Context(), query)
+		if err != nil {
+			switch err {
+			case domain.ErrShortLinkNotFound:
+				responder.NotFound("Link not found.")
+			case domain.ErrInvalidListQuery:
+				responder.BadRequest("Invalid query parameters.")
+			case domain.ErrInvalidCursor:
+				responder.BadRequest("Invalid cursor.")
+			case domain.ErrUnknownDomain:
+				responder.BadRequest("Unknown domain.")
+			default:
+				h.logger.Error(
+					"Handler.clicks",
+					slog.Any("error", err),
+				)
+				responder.ServerError()
+			}
+			return
+		}
+
+		resDTO := clicksResponseDTO{
+			Items:      make([]clickEventDTO, 0, len(result.Events)),
+			NextCursor: result.NextCursor,
+		}
+		for _, event := range result.Events {
+			resDTO.Items = append(resDTO.Items, clickEventDTO{
+				Timestamp:      event.Timestamp,
+				Referrer:       event.Referrer,
+				UserAgent:      event.UserAgent,
+				IP:             event.IP,
+				AcceptLanguage: event.AcceptLanguage,
+				Country:        event.Country,
+				Bot:            event.Bot,
+			})
+		}
+
+		responder.OK(resDTO)
+	})
+}
+
+func (h *handler) history() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responder := httputil.NewJsonResponder(w, h.logger)
+
+		query := domain.HistoryQuery{
+			LinkKey:    r.PathValue("linkKey"),
+			LinkDomain: r.URL.Query().Get("domain"),
+			Cursor:     r.URL.Query().Get("cursor"),
+		}
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			var err error
+			if query.Limit, err = strconv.Atoi(limit); err != nil {
+				responder.BadRequest("Invalid query parameters.")
+				return
+			}
+		}
+
+		result, err := h.usecase.History(r.Context(), query)
+		if err != nil {
+			switch err {
+			case domain.ErrInvalidListQuery:
+				responder.BadRequest("Invalid query parameters.")
+			case domain.ErrInvalidCursor:
+				responder.BadRequest("Invalid cursor.")
+			case domain.ErrUnknownDomain:
+				responder.BadRequest("Unknown domain.")
+			default:
+				h.logger.Error(
+					"Handler.history",
+					slog.Any("error", err),
+				)
+				responder.ServerError()
+			}
+			return
+		}
+
+		resDTO := historyResponseDTO{
+			Items:      make([]revisionDTO, 0, len(result.Revisions)),
+			NextCursor: result.NextCursor,
+		}
+		for _, revision := range result.Revisions {
+			resDTO.Items = append(resDTO.Items, newRevisionDTO(revision))
+		}
+
+		responder.OK(resDTO)
+	})
+}
+
+// linkRef returns the link of a management route, its short domain is the "domain" query parameter.
+func linkRef(r *http.Request) domain.LinkRef {
+	return domain.LinkRef{
+		Domain: r.URL.Query().Get("domain"),
+		Key:    r.PathValue("linkKey"),
+	}
+}
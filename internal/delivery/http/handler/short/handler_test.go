@@ -0,0 +1,317 @@
+package short_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/handler/short"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/internal/usecase"
+	"github.com/OsoianMarcel/url-shortener/pkg/botdetect"
+)
+
+const notFoundURL = "https://example.com/not-found"
+
+type stubShortLinkUsecase struct {
+	domain.ShortLinkUsecase
+	originalURL string
+	// redirect status of the link, 302 when zero
+	redirectStatus int
+	// password of a protected link
+	password string
+	err      error
+}
+
+func (u *stubShortLinkUsecase) Redirect(ctx context.Context, request domain.RedirectRequest) (domain.Redirect, error) {
+	if u.password != "" && request.Password == "" {
+		return domain.Redirect{}, domain.ErrPasswordRequired
+	}
+	if u.password != "" && request.Password != u.password {
+		return domain.Redirect{}, domain.ErrWrongPassword
+	}
+
+	status := u.redirectStatus
+	if status == 0 {
+		status = http.StatusFound
+	}
+
+	return domain.Redirect{URL: u.originalURL, StatusCode: status}, u.err
+}
+
+func (u *stubShortLinkUsecase) Expand(ctx context.Context, link domain.LinkRef, attempt domain.PasswordAttempt) (domain.ShortLink, error) {
+	return domain.ShortLink{Key: link.Key, OriginalURL: u.originalURL}, u.err
+}
+
+func TestRedirect(t *testing.T) {
+	tests := []struct {
+		name    string
+		usecase *stubShortLinkUsecase
+		// a POST of the form when not empty
+		form             url.Values
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:             "Found",
+			usecase:          &stubShortLinkUsecase{originalURL: "https://example.com/page"},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://example.com/page",
+		},
+		{
+			name:             "Not Found",
+			usecase:          &stubShortLinkUsecase{err: domain.ErrShortLinkNotFound},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: notFoundURL,
+		},
+		{
+			name:             "Exhausted",
+			usecase:          &stubShortLinkUsecase{err: domain.ErrShortLinkExhausted},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: notFoundURL,
+		},
+		{
+			name:           "Expired",
+			usecase:        &stubShortLinkUsecase{err: domain.ErrShortLinkExpired},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:           "Password Required",
+			usecase:        &stubShortLinkUsecase{originalURL: "https://example.com/page", password: "secret"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			// a 307 would post the password to the destination
+			name:             "Correct Password",
+			usecase:          &stubShortLinkUsecase{originalURL: "https://example.com/page", redirectStatus: http.StatusTemporaryRedirect, password: "secret"},
+			form:             url.Values{"password": {"secret"}},
+			expectedStatus:   http.StatusSeeOther,
+			expectedLocation: "https://example.com/page",
+		},
+		{
+			name:           "Wrong Password",
+			usecase:        &stubShortLinkUsecase{originalURL: "https://example.com/page", password: "secret"},
+			form:           url.Values{"password": {"guess"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			// the body of a POST to a public link is not read, and a 307 keeps the method for the destination
+			name:             "Post To Public Link",
+			usecase:          &stubShortLinkUsecase{originalURL: "https://example.com/page", redirectStatus: http.StatusTemporaryRedirect},
+			form:             url.Values{"password": {"secret"}},
+			expectedStatus:   http.StatusTemporaryRedirect,
+			expectedLocation: "https://example.com/page",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			short.RegisterHandler(mux, slog.New(slog.NewTextHandler(io.Discard, nil)), tt.usecase, nil, nil, short.RateLimits{}, short.NotFoundRedirects{Default: notFoundURL}, false, false, short.DisabledLinkNotFound)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/shortener/abc/redirect", nil)
+			if tt.form != nil {
+				req = httptest.NewRequest(http.MethodPost, "/api/shortener/abc/redirect", strings.NewReader(tt.form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("status = %d; want %d", rec.Code, tt.expectedStatus)
+			}
+			if location := rec.Header().Get("Location"); location != tt.expectedLocation {
+				t.Errorf("Location = %q; want %q", location, tt.expectedLocation)
+			}
+		})
+	}
+}
+
+func TestDisabledLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		response short.DisabledLinkResponse
+		// status and location of the redirect, and status of the expand API
+		expectedRedirectStatus   int
+		expectedRedirectLocation string
+		expectedExpandStatus     int
+	}{
+		{name: "Not Found", response: short.DisabledLinkNotFound, expectedRedirectStatus: http.StatusFound, expectedRedirectLocation: notFoundURL, expectedExpandStatus: http.StatusNotFound},
+		{name: "Paused Page", response: short.DisabledLinkPage, expectedRedirectStatus: http.StatusServiceUnavailable, expectedExpandStatus: http.StatusServiceUnavailable},
+		{name: "Unavailable For Legal Reasons", response: short.DisabledLinkUnavailableForLegalReasons, expectedRedirectStatus: http.StatusUnavailableForLegalReasons, expectedExpandStatus: http.StatusUnavailableForLegalReasons},
+		{name: "Forbidden", response: short.DisabledLinkForbidden, expectedRedirectStatus: http.StatusForbidden, expectedExpandStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			stub := &stubShortLinkUsecase{originalURL: "https://example.com/page", err: domain.ErrShortLinkDisabled}
+			short.RegisterHandler(mux, slog.New(slog.NewTextHandler(io.Discard, nil)), stub, nil, nil, short.RateLimits{}, short.NotFoundRedirects{Default: notFoundURL}, false, false, tt.response)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/abc", nil))
+			if rec.Code != tt.expectedRedirectStatus {
+				t.Errorf("redirect status = %d; want %d", rec.Code, tt.expectedRedirectStatus)
+			}
+			if location := rec.Header().Get("Location"); location != tt.expectedRedirectLocation {
+				t.Errorf("redirect location = %q; want %q", location, tt.expectedRedirectLocation)
+			}
+
+			rec = httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/shortener/abc/expand", nil))
+			if rec.Code != tt.expectedExpandStatus {
+				t.Errorf("expand status = %d; want %d", rec.Code, tt.expectedExpandStatus)
+			}
+			if strings.Contains(rec.Body.String(), stub.originalURL) {
+				t.Errorf("expand body = %q; want no destination", rec.Body.String())
+			}
+		})
+	}
+}
+
+// stubShortLinkRepo serves a single link of the primary domain, the other methods are not called.
+type stubShortLinkRepo struct {
+	domain.ShortLinkRepo
+	link domain.ShortLink
+}
+
+func (s *stubShortLinkRepo) FindOne(_ context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	if link != s.link.Ref() {
+		return domain.ShortLink{}, domain.ErrShortLinkNotFound
+	}
+
+	return s.link, nil
+}
+
+func (s *stubShortLinkRepo) FindRedirectTarget(_ context.Context, link domain.LinkRef) (domain.RedirectTarget, error) {
+	if link != s.link.Ref() {
+		return domain.RedirectTarget{}, domain.ErrShortLinkNotFound
+	}
+
+	return domain.RedirectTarget{LinkID: s.link.ID, OriginalURL: s.link.OriginalURL}, nil
+}
+
+func (s *stubShortLinkRepo) IncreaseHits(context.Context, domain.LinkRef, bool) error {
+	return nil
+}
+
+// stubUniqueVisitorRepo keeps the link IDs of the added visitors.
+type stubUniqueVisitorRepo struct {
+	domain.UniqueVisitorRepo
+	linkIDs []string
+}
+
+func (s *stubUniqueVisitorRepo) Add(_ context.Context, linkID string, _ string, _ time.Time) error {
+	s.linkIDs = append(s.linkIDs, linkID)
+	return nil
+}
+
+type stubClickRecorder struct {
+	events []domain.ClickEvent
+}
+
+func (s *stubClickRecorder) Record(event domain.ClickEvent) {
+	s.events = append(s.events, event)
+}
+
+func newTestMux(link domain.ShortLink, uniqueVisitorRepo domain.UniqueVisitorRepo, clickRecorder domain.ClickRecorder) *http.ServeMux {
+	shortLinkUsecase := usecase.NewShortLinkUsecase(
+		slog.Default(),
+		&stubShortLinkRepo{link: link},
+		nil,
+		uniqueVisitorRepo,
+		nil,
+		nil,
+		clickRecorder,
+		botdetect.New(nil),
+		nil,
+		nil,
+		usecase.RedirectOptions{DefaultStatus: http.StatusFound},
+		usecase.Domains{Primary: "sho.rt", Others: []string{"brnd.io"}},
+		func(host string, key string) string { return "https://" + host + "/" + key },
+	)
+
+	mux := http.NewServeMux()
+	short.RegisterHandler(
+		mux,
+		slog.Default(),
+		shortLinkUsecase,
+		nil,
+		nil,
+		short.RateLimits{},
+		short.NotFoundRedirects{Default: "https://sho.rt/404"},
+		false,
+		true,
+		short.DisabledLinkNotFound,
+	)
+
+	return mux
+}
+
+func TestPreviewAndRedirectAgreeOnHost(t *testing.T) {
+	link := domain.NewShortLink("abc123", "https://example.com/landing")
+	mux := newTestMux(link, &stubUniqueVisitorRepo{}, &stubClickRecorder{})
+
+	tests := []struct {
+		name  string
+		host  string
+		found bool
+	}{
+		{name: "Primary Domain", host: "sho.rt", found: true},
+		{name: "Unknown Host", host: "10.0.0.5:3000", found: true},
+		{name: "Internal Hostname", host: "shortener.internal", found: true},
+		{name: "Other Domain", host: "brnd.io", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redirect := httptest.NewRecorder()
+			redirectReq := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			redirectReq.Host = tt.host
+			mux.ServeHTTP(redirect, redirectReq)
+
+			preview := httptest.NewRecorder()
+			previewReq := httptest.NewRequest(http.MethodGet, "/abc123+", nil)
+			previewReq.Host = tt.host
+			mux.ServeHTTP(preview, previewReq)
+
+			redirectFound := redirect.Header().Get("Location") == link.OriginalURL
+			previewFound := preview.Code == http.StatusOK
+			if redirectFound != tt.found {
+				t.Errorf("redirect found = %v; want %v (status %d, location %q)",
+					redirectFound, tt.found, redirect.Code, redirect.Header().Get("Location"))
+			}
+			if previewFound != redirectFound {
+				t.Errorf("preview found = %v; redirect found = %v (preview status %d)", previewFound, redirectFound, preview.Code)
+			}
+		})
+	}
+}
+
+func TestRedirectRecordsVisitByLinkID(t *testing.T) {
+	link := domain.NewShortLink("abc123", "https://example.com/landing")
+	link.ID = "65f1a2b3c4d5e6f708091a2b"
+	uniqueVisitorRepo := &stubUniqueVisitorRepo{}
+	clickRecorder := &stubClickRecorder{}
+	mux := newTestMux(link, uniqueVisitorRepo, clickRecorder)
+
+	req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	req.Host = "sho.rt"
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0")
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+
+	// a key reused by a later link must not inherit the stats of this one
+	if len(uniqueVisitorRepo.linkIDs) != 1 || uniqueVisitorRepo.linkIDs[0] != link.ID {
+		t.Errorf("unique visitor link IDs = %v; want [%s]", uniqueVisitorRepo.linkIDs, link.ID)
+	}
+	if len(clickRecorder.events) != 1 || clickRecorder.events[0].LinkID != link.ID {
+		t.Errorf("click events = %+v; want one event of link %s", clickRecorder.events, link.ID)
+	}
+}
@@ -0,0 +1,53 @@
+package short
+
+import (
+	"html/template"
+	"log/slog"
+	"net/http"
+)
+
+// the form posts to the URL it is served from, so no link data is rendered into the page
+var passwordFormTemplate = template.Must(template.New("passwordForm").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+<style>
+body { font-family: sans-serif; max-width: 24rem; margin: 4rem auto; padding: 0 1rem; }
+input, button { font-size: 1rem; padding: .5rem; width: 100%; box-sizing: border-box; margin-top: .5rem; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>Password required</h1>
+<p>This link is protected. Enter the password to continue.</p>
+{{if .}}<p class="error" role="alert">{{.}}</p>{{end}}
+<form method="post">
+<label for="password">Password</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// maxPasswordFormSize limits the body of the posted password form.
+const maxPasswordFormSize = 4 << 10
+
+// renderPasswordForm serves the form asking the password of a protected link, with an optional error message.
+func renderPasswordForm(w http.ResponseWriter, logger *slog.Logger, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(status)
+
+	if err := passwordFormTemplate.Execute(w, message); err != nil {
+		logger.Warn(
+			"failed to render the password form",
+			slog.Any("error", err),
+		)
+	}
+}
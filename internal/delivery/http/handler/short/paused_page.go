@@ -0,0 +1,41 @@
+package short
+
+import (
+	"html/template"
+	"log/slog"
+	"net/http"
+)
+
+var pausedPageTemplate = template.Must(template.New("pausedPage").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link paused</title>
+<style>
+body { font-family: sans-serif; max-width: 32rem; margin: 4rem auto; padding: 0 1rem; }
+</style>
+</head>
+<body>
+<h1>Link paused</h1>
+<p>This link has been paused by its owner, try again later.</p>
+</body>
+</html>
+`))
+
+// renderPausedPage serves the page of a disabled link. The link is expected to be enabled again,
+// so the page is served as temporarily unavailable and is never cached.
+func renderPausedPage(w http.ResponseWriter, logger *slog.Logger) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.WriteHeader(http.StatusServiceUnavailable)
+
+	if err := pausedPageTemplate.Execute(w, nil); err != nil {
+		logger.Warn(
+			"failed to render the paused page",
+			slog.Any("error", err),
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package short
+
+import (
+	"html/template"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// previewPageData is rendered into the preview page, the destination of a protected link is never shown.
+type previewPageData struct {
+	// Relative URL of the redirect, served next to the preview URL
+	Key         string
+	Destination string
+	ExpiresAt   time.Time
+	Protected   bool
+}
+
+var previewPageTemplate = template.Must(template.New("previewPage").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link preview</title>
+<style>
+body { font-family: sans-serif; max-width: 32rem; margin: 4rem auto; padding: 0 1rem; }
+.destination { word-break: break-all; font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>Link preview</h1>
+{{if .Protected}}<p>This link is password protected, the destination is shown after the password is entered.</p>
+{{else}}<p>This link leads to:</p>
+<p class="destination">{{.Destination}}</p>
+{{end}}{{if not .ExpiresAt.IsZero}}<p>The link expires on {{.ExpiresAt.UTC.Format "2006-01-02 15:04 MST"}}.</p>
+{{end}}<p><a href="./{{.Key}}" rel="noreferrer">Continue</a></p>
+</body>
+</html>
+`))
+
+// renderPreviewPage serves the page describing where a link leads, without following it.
+func renderPreviewPage(w http.ResponseWriter, logger *slog.Logger, data previewPageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(http.StatusOK)
+
+	if err := previewPageTemplate.Execute(w, data); err != nil {
+		logger.Warn(
+			"failed to render the preview page",
+			slog.Any("error", err),
+		)
+	}
+}
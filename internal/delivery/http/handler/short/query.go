@@ -0,0 +1,149 @@
+package short
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+var errInvalidQueryParam = errors.New("invalid query parameter")
+
+// parseListQuery maps the query string of the list endpoint to the domain query.
+// Only the format is checked here, the values are validated by the usecase.
+func parseListQuery(values url.Values) (domain.ListQuery, error) {
+	query := domain.ListQuery{
+		Host:      values.Get("host"),
+		KeyPrefix: values.Get("key_prefix"),
+		Cursor:    values.Get("cursor"),
+	}
+
+	var err error
+	if query.CreatedAfter, err = parseTimeParam(values.Get("created_after")); err != nil {
+		return domain.ListQuery{}, err
+	}
+	if query.CreatedBefore, err = parseTimeParam(values.Get("created_before")); err != nil {
+		return domain.ListQuery{}, err
+	}
+
+	switch values.Get("sort") {
+	case "", "created_at":
+		query.SortBy = domain.ListSortByCreatedAt
+	case "hits":
+		query.SortBy = domain.ListSortByHits
+	default:
+		return domain.ListQuery{}, errInvalidQueryParam
+	}
+
+	switch values.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return domain.ListQuery{}, errInvalidQueryParam
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return domain.ListQuery{}, errInvalidQueryParam
+		}
+	}
+
+	if deleted := values.Get("deleted"); deleted != "" {
+		if query.Deleted, err = strconv.ParseBool(deleted); err != nil {
+			return domain.ListQuery{}, errInvalidQueryParam
+		}
+	}
+
+	return query, nil
+}
+
+// parseStatsQuery maps the query string of the stats endpoint to the domain query.
+func parseStatsQuery(key string, values url.Values) (domain.StatsQuery, error) {
+	query := domain.StatsQuery{
+		Key:    key,
+		Domain: values.Get("domain"),
+		Bucket: domain.StatsBucket(values.Get("bucket")),
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(values.Get("from")); err != nil {
+		return domain.StatsQuery{}, err
+	}
+	if query.To, err = parseTimeParam(values.Get("to")); err != nil {
+		return domain.StatsQuery{}, err
+	}
+
+	if top := values.Get("top"); top != "" {
+		if query.TopLimit, err = strconv.Atoi(top); err != nil {
+			return domain.StatsQuery{}, errInvalidQueryParam
+		}
+	}
+
+	return query, nil
+}
+
+// parseExportQuery maps the query string of the export endpoint to the domain query.
+func parseExportQuery(values url.Values) (domain.ExportQuery, error) {
+	query := domain.ExportQuery{
+		Domain: values.Get("domain"),
+	}
+
+	var err error
+	if query.CreatedAfter, err = parseTimeParam(values.Get("created_after")); err != nil {
+		return domain.ExportQuery{}, err
+	}
+	if query.CreatedBefore, err = parseTimeParam(values.Get("created_before")); err != nil {
+		return domain.ExportQuery{}, err
+	}
+
+	return query, nil
+}
+
+// parseQRCodeQuery maps the query string of the QR code endpoint to the domain query.
+func parseQRCodeQuery(key string, values url.Values) (domain.QRCodeQuery, error) {
+	query := domain.QRCodeQuery{
+		Key:        key,
+		Domain:     values.Get("domain"),
+		Format:     domain.QRCodeFormat(values.Get("format")),
+		Level:      values.Get("level"),
+		Foreground: values.Get("fg"),
+		Background: values.Get("bg"),
+	}
+
+	var err error
+	if size := values.Get("size"); size != "" {
+		if query.Size, err = strconv.Atoi(size); err != nil {
+			return domain.QRCodeQuery{}, errInvalidQueryParam
+		}
+	}
+	if margin := values.Get("margin"); margin != "" {
+		m, err := strconv.Atoi(margin)
+		if err != nil {
+			return domain.QRCodeQuery{}, errInvalidQueryParam
+		}
+		query.Margin = &m
+	}
+	if logo := values.Get("logo"); logo != "" {
+		if query.Logo, err = strconv.ParseBool(logo); err != nil {
+			return domain.QRCodeQuery{}, errInvalidQueryParam
+		}
+	}
+
+	return query, nil
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errInvalidQueryParam
+	}
+
+	return t, nil
+}
@@ -0,0 +1,21 @@
+package httputil
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GetCountry returns the client's country code set by a trusted proxy, or an empty string.
+func GetCountry(r *http.Request, trustProxy bool) string {
+	if !trustProxy {
+		return ""
+	}
+
+	// Cloudflare header, "XX" is used when the country is unknown
+	country := strings.ToUpper(strings.TrimSpace(r.Header.Get("CF-IPCountry")))
+	if len(country) != 2 || country == "XX" {
+		return ""
+	}
+
+	return country
+}
@@ -78,10 +78,18 @@ func (j *jsonResponder) Forbidden(message string) {
 	j.Error(http.StatusForbidden, message)
 }
 
+func (j *jsonResponder) Gone(message string) {
+	j.Error(http.StatusGone, message)
+}
+
 func (j *jsonResponder) Conflict(message string) {
 	j.Error(http.StatusConflict, message)
 }
 
+func (j *jsonResponder) TooManyRequests(message string) {
+	j.Error(http.StatusTooManyRequests, message)
+}
+
 func (j *jsonResponder) BadRequest(message string) {
 	j.Error(http.StatusBadRequest, message)
 }
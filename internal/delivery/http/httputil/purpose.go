@@ -0,0 +1,17 @@
+package httputil
+
+import "net/http"
+
+// purposeHeaders are set by browsers and proxies when a page is loaded speculatively.
+var purposeHeaders = []string{"Sec-Purpose", "Purpose", "X-Purpose", "X-Moz"}
+
+// GetPurpose returns the first non-empty prefetch purpose header, or an empty string.
+func GetPurpose(r *http.Request) string {
+	for _, header := range purposeHeaders {
+		if value := r.Header.Get(header); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
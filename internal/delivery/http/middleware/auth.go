@@ -1,15 +1,17 @@
 package middleware
 
 import (
-	"crypto/subtle"
 	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/httputil"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
 )
 
-func AuthenticationMiddleware(apiSecret string, logger *slog.Logger) Middleware {
+// AuthenticationMiddleware accepts only the requests with an API key or a JWT which grants the scope.
+// The authenticated caller is available to the next handlers through domain.PrincipalFromContext.
+func AuthenticationMiddleware(authUsecase domain.AuthUsecase, scope domain.Scope, logger *slog.Logger) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			responder := httputil.NewJsonResponder(w, logger)
@@ -26,13 +28,24 @@ func AuthenticationMiddleware(apiSecret string, logger *slog.Logger) Middleware
 				return
 			}
 
-			token := authParts[1]
-			if subtle.ConstantTimeCompare([]byte(token), []byte(apiSecret)) != 1 {
-				responder.Unauthorized("Invalid token.")
+			principal, err := authUsecase.Authenticate(r.Context(), authParts[1])
+			if err != nil {
+				if err == domain.ErrInvalidToken {
+					responder.Unauthorized("Invalid token.")
+					return
+				}
+
+				logger.Error("AuthenticationMiddleware", slog.Any("error", err))
+				responder.ServerError()
+				return
+			}
+
+			if !principal.HasScope(scope) {
+				responder.Forbidden("The token lacks the required scope.")
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(domain.ContextWithPrincipal(r.Context(), principal)))
 		})
 	}
 }
@@ -1,6 +1,7 @@
 package middleware_test
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -8,10 +9,29 @@ import (
 	"testing"
 
 	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/middleware"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
 )
 
+// stubAuthUsecase knows a single token per principal.
+type stubAuthUsecase struct {
+	principals map[string]domain.Principal
+}
+
+func (s stubAuthUsecase) Authenticate(_ context.Context, token string) (domain.Principal, error) {
+	principal, ok := s.principals[token]
+	if !ok {
+		return domain.Principal{}, domain.ErrInvalidToken
+	}
+
+	return principal, nil
+}
+
 func TestAuthorizationMiddleware(t *testing.T) {
-	apiSecret := "secret123"
+	authUsecase := stubAuthUsecase{principals: map[string]domain.Principal{
+		"secret123": {Owner: "ci", Scopes: []domain.Scope{domain.ScopeLinksCreate}},
+		"stats123":  {Owner: "reports", Scopes: []domain.Scope{domain.ScopeLinksStats}},
+		"admin123":  {Owner: "admin", Scopes: []domain.Scope{domain.ScopeAdmin}},
+	}}
 
 	tests := []struct {
 		name           string
@@ -55,6 +75,18 @@ func TestAuthorizationMiddleware(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   "success",
 		},
+		{
+			name:           "Token Without Required Scope",
+			authHeader:     "Bearer stats123",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"The token lacks the required scope."}`,
+		},
+		{
+			name:           "Admin Token",
+			authHeader:     "Bearer admin123",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "success",
+		},
 		{
 			name:           "Invalid Authorization Header With Extra Parts",
 			authHeader:     "Bearer secret123 extra",
@@ -81,7 +113,7 @@ func TestAuthorizationMiddleware(t *testing.T) {
 			})
 
 			// Wrap the test handler with the AuthorizationMiddleware
-			handler := middleware.AuthenticationMiddleware(apiSecret, slog.Default())(nextHandler)
+			handler := middleware.AuthenticationMiddleware(authUsecase, domain.ScopeLinksCreate, slog.Default())(nextHandler)
 
 			// Serve the HTTP request
 			handler.ServeHTTP(rr, req)
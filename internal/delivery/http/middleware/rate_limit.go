@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/httputil"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// RateLimitMiddleware rejects the requests of a client above the limit of the route.
+// The client is the authenticated principal when the middleware runs after the authentication,
+// otherwise the client IP. The requests are allowed when the limiter is unavailable.
+func RateLimitMiddleware(
+	limiter domain.RateLimiter,
+	route string,
+	limit domain.RateLimit,
+	trustProxy bool,
+	logger *slog.Logger,
+) Middleware {
+	return func(next http.Handler) http.Handler {
+		if !limit.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := "ip:" + httputil.GetRealIP(r, trustProxy)
+			if principal, ok := domain.PrincipalFromContext(r.Context()); ok {
+				client = "owner:" + principal.Owner
+			}
+
+			result, err := limiter.Allow(r.Context(), route, client, limit)
+			if err != nil {
+				logger.Warn("rate limiter is unavailable, allowing the request",
+					slog.String("route", route),
+					slog.Any("error", err),
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !result.Allowed {
+				retryAfter := max(1, int(math.Ceil(result.RetryAfter.Seconds())))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				httputil.NewJsonResponder(w, logger).TooManyRequests("Too many requests.")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
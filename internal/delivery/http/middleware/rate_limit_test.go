@@ -0,0 +1,112 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/delivery/http/middleware"
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// stubRateLimiter allows a fixed number of requests per client and records the clients.
+type stubRateLimiter struct {
+	counts map[string]int
+	err    error
+}
+
+func (s *stubRateLimiter) Allow(_ context.Context, route string, client string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	if s.err != nil {
+		return domain.RateLimitResult{}, s.err
+	}
+
+	s.counts[route+" "+client]++
+	if s.counts[route+" "+client] > limit.Requests {
+		return domain.RateLimitResult{RetryAfter: 1500 * time.Millisecond}, nil
+	}
+
+	return domain.RateLimitResult{Allowed: true}, nil
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limit := domain.RateLimit{Requests: 1, Window: time.Minute}
+
+	tests := []struct {
+		name               string
+		limit              domain.RateLimit
+		limiterErr         error
+		principal          *domain.Principal
+		expectedStatus     int
+		expectedRetryAfter string
+		expectedClient     string
+	}{
+		{
+			name:               "Limited By IP",
+			limit:              limit,
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+			expectedClient:     "redirect ip:192.0.2.1",
+		},
+		{
+			name:               "Limited By Principal",
+			limit:              limit,
+			principal:          &domain.Principal{Owner: "key-1"},
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+			expectedClient:     "redirect owner:key-1",
+		},
+		{
+			name:           "Disabled Limit",
+			limit:          domain.RateLimit{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Limiter Unavailable",
+			limit:          limit,
+			limiterErr:     errors.New("redis is down"),
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &stubRateLimiter{counts: map[string]int{}, err: tt.limiterErr}
+
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := middleware.RateLimitMiddleware(limiter, "redirect", tt.limit, false, slog.Default())(nextHandler)
+
+			// the second request of the client exceeds the limit
+			var rr *httptest.ResponseRecorder
+			for range 2 {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = "192.0.2.1:5000"
+				if tt.principal != nil {
+					req = req.WithContext(domain.ContextWithPrincipal(req.Context(), *tt.principal))
+				}
+
+				rr = httptest.NewRecorder()
+				handler.ServeHTTP(rr, req)
+			}
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if got := rr.Header().Get("Retry-After"); got != tt.expectedRetryAfter {
+				t.Errorf("expected Retry-After %q, got %q", tt.expectedRetryAfter, got)
+			}
+			if tt.expectedClient != "" && limiter.counts[tt.expectedClient] != 2 {
+				t.Errorf("expected 2 requests of %q, got %v", tt.expectedClient, limiter.counts)
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests && !strings.Contains(rr.Body.String(), "Too many requests.") {
+				t.Errorf("unexpected body %q", rr.Body.String())
+			}
+		})
+	}
+}
@@ -13,6 +13,11 @@ func RecoverMiddleware(logger *slog.Logger) Middleware {
 			// recover from panics and handle errors
 			defer func() {
 				if r := recover(); r != nil {
+					// the handler aborts a response it already started, the server closes the connection
+					if r == http.ErrAbortHandler {
+						panic(r)
+					}
+
 					logger.Error("Middlewares.RecoverMiddleware.", slog.Any("error", r))
 					responder := httputil.NewJsonResponder(w, logger)
 					responder.ServerError()
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// TransportMiddleware marks the requests as HTTP ones, for the actor recorded in the history of the links.
+func TransportMiddleware() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(domain.ContextWithTransport(r.Context(), domain.TransportHTTP)))
+		})
+	}
+}
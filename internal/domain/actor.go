@@ -0,0 +1,45 @@
+package domain
+
+import "context"
+
+// Transport is the delivery layer an operation is requested through.
+type Transport string
+
+const (
+	TransportHTTP Transport = "http"
+	TransportGRPC Transport = "grpc"
+	TransportCLI  Transport = "cli"
+)
+
+// Actor is the caller of an operation, as recorded in the history of the links.
+type Actor struct {
+	// API key ID or token subject, empty when the caller is not authenticated, e.g. on the CLI
+	Owner string
+	// Empty when the caller is not authenticated
+	Method    AuthMethod
+	Transport Transport
+}
+
+type transportContextKey struct{}
+
+func ContextWithTransport(ctx context.Context, transport Transport) context.Context {
+	return context.WithValue(ctx, transportContextKey{}, transport)
+}
+
+// TransportFromContext returns the transport set by the delivery layer, empty when none is set.
+func TransportFromContext(ctx context.Context) Transport {
+	transport, _ := ctx.Value(transportContextKey{}).(Transport)
+
+	return transport
+}
+
+// ActorFromContext returns the caller of the operation from the principal and the transport of the context.
+func ActorFromContext(ctx context.Context) Actor {
+	actor := Actor{Transport: TransportFromContext(ctx)}
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		actor.Owner = principal.Owner
+		actor.Method = principal.Method
+	}
+
+	return actor
+}
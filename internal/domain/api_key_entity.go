@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type APIKey struct {
+	// Primary key
+	ID   string
+	Name string
+	// The first characters of the token, to recognize the key without revealing it
+	Prefix string
+	// SHA-256 of the token, the token itself is never stored
+	Hash       string
+	Scopes     []Scope
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	// Zero value means the key is active
+	RevokedAt time.Time
+}
+
+func (k APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
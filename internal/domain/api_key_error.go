@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+var (
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrInvalidAPIKeyName  = errors.New("invalid api key name")
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope")
+)
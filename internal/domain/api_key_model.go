@@ -0,0 +1,12 @@
+package domain
+
+type CreateAPIKeyAction struct {
+	Name   string
+	Scopes []Scope
+}
+
+type CreateAPIKeyResult struct {
+	Key APIKey
+	// The plain token, it is returned only once
+	Token string
+}
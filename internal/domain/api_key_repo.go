@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type APIKeyRepo interface {
+	InsertOne(ctx context.Context, key APIKey) (string, error)
+	FindByHash(ctx context.Context, hash string) (APIKey, error)
+	// FindAll returns every key, including the revoked ones, newest first
+	FindAll(ctx context.Context) ([]APIKey, error)
+	Revoke(ctx context.Context, id string, at time.Time) error
+	UpdateLastUsed(ctx context.Context, id string, at time.Time) error
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type APIKeyUsecase interface {
+	Create(ctx context.Context, createInput CreateAPIKeyAction) (CreateAPIKeyResult, error)
+	List(ctx context.Context) ([]APIKey, error)
+	Revoke(ctx context.Context, id string) error
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrInvalidToken is returned for unknown, revoked, expired or malformed credentials
+	ErrInvalidToken = errors.New("invalid token")
+)
@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+type AuthUsecase interface {
+	// Authenticate resolves an API key or a JWT into the caller, or returns ErrInvalidToken
+	Authenticate(ctx context.Context, token string) (Principal, error)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// maxClickFieldLength limits the size of client supplied headers stored with a click.
+const maxClickFieldLength = 512
+
+// Visit describes the client following a short link.
+type Visit struct {
+	Referrer       string
+	UserAgent      string
+	IP             string
+	AcceptLanguage string
+	// ISO 3166-1 alpha-2 country code, when provided by a trusted proxy
+	Country string
+	// Value of the prefetch purpose header, e.g. "prefetch" for speculative loads
+	Purpose string
+}
+
+// VisitorID identifies the client by a hash of its IP address and user agent,
+// so distinct visitors can be counted without storing them.
+func (v Visit) VisitorID() string {
+	sum := sha256.Sum256([]byte(v.IP + "\x00" + v.UserAgent))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+type ClickEvent struct {
+	// Primary key
+	ID string
+	// ID of the link, a key reused by a later link does not inherit the events of the previous one
+	LinkID string
+	// LinkRef.String() of the link
+	LinkKey   string
+	Timestamp time.Time
+	// The click was made by a crawler, a link preview service or a prefetch
+	Bot bool
+	Visit
+}
+
+func NewClickEvent(link LinkRef, linkID string, visit Visit, bot bool) ClickEvent {
+	return ClickEvent{
+		LinkID:    linkID,
+		LinkKey:   link.String(),
+		Timestamp: time.Now(),
+		Bot:       bot,
+		Visit: Visit{
+			Referrer:       truncate(visit.Referrer, maxClickFieldLength),
+			UserAgent:      truncate(visit.UserAgent, maxClickFieldLength),
+			IP:             visit.IP,
+			AcceptLanguage: truncate(visit.AcceptLanguage, maxClickFieldLength),
+			Country:        truncate(visit.Country, 2),
+			Purpose:        truncate(visit.Purpose, maxClickFieldLength),
+		},
+	}
+}
+
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+
+	return s[:maxLength]
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+type ClickQuery struct {
+	LinkKey string
+	// Short domain of the link, the primary domain when empty
+	LinkDomain string
+	// Set by the usecase from the found link
+	LinkID        string
+	LinkCreatedAt time.Time
+	Limit         int
+	// Opaque cursor returned by the previous page
+	Cursor string
+}
+
+type ClickAnalyticsQuery struct {
+	LinkKey string
+	// Short domain of the link, the primary domain when empty
+	LinkDomain string
+	// Set by the usecase from the found link
+	LinkID        string
+	LinkCreatedAt time.Time
+	Bucket        StatsBucket
+	From          time.Time
+	To            time.Time
+	TopLimit      int
+}
+
+// ClickAnalytics aggregates the click events of a link over a time range.
+type ClickAnalytics struct {
+	// Click counts per bucket start, in chronological order
+	Timeline      []StatsPoint
+	TopReferrers  []StatsCount
+	TopCountries  []StatsCount
+	TopUserAgents []StatsCount
+}
+
+type StatsPoint struct {
+	Start time.Time
+	// Human clicks
+	Clicks    uint
+	BotClicks uint
+}
+
+type StatsCount struct {
+	Value  string
+	Clicks uint
+}
+
+type ClickResult struct {
+	// Newest events first
+	Events []ClickEvent
+	// Empty when there are no more pages
+	NextCursor string
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+type ClickEventRepo interface {
+	InsertMany(ctx context.Context, events []ClickEvent) error
+	FindMany(ctx context.Context, query ClickQuery) (ClickResult, error)
+	// Aggregate returns the analytics without empty timeline buckets
+	Aggregate(ctx context.Context, query ClickAnalyticsQuery) (ClickAnalytics, error)
+}
+
+// ClickRecorder stores click events in the background, so recording never delays a redirect.
+type ClickRecorder interface {
+	Record(event ClickEvent)
+}
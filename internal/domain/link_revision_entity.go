@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// RevisionAction is the change of a link recorded by a revision.
+type RevisionAction string
+
+const (
+	RevisionActionCreate  RevisionAction = "create"
+	RevisionActionUpdate  RevisionAction = "update"
+	RevisionActionDelete  RevisionAction = "delete"
+	RevisionActionRestore RevisionAction = "restore"
+	RevisionActionDisable RevisionAction = "disable"
+	RevisionActionEnable  RevisionAction = "enable"
+)
+
+// FieldChange is the change of a field of a link, the values are formatted as strings and empty when the field is unset.
+type FieldChange struct {
+	// Name of the field in the HTTP API, e.g. "url" or "expires_at"
+	Field string
+	Old   string
+	New   string
+}
+
+// LinkRevision is the immutable record of a change of a link.
+type LinkRevision struct {
+	// Primary key
+	ID string
+	// ID of the changed link, it tells apart the links which reused the key of a purged link
+	LinkID string
+	// Short domain of the link, empty for the primary domain
+	Domain    string
+	Key       string
+	Action    RevisionAction
+	Actor     Actor
+	CreatedAt time.Time
+	// Changed fields, in a stable order
+	Changes []FieldChange
+}
+
+func NewLinkRevision(link ShortLink, action RevisionAction, actor Actor, changes []FieldChange) LinkRevision {
+	return LinkRevision{
+		LinkID:    link.ID,
+		Domain:    link.Domain,
+		Key:       link.Key,
+		Action:    action,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+		Changes:   changes,
+	}
+}
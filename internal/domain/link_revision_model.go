@@ -0,0 +1,17 @@
+package domain
+
+type HistoryQuery struct {
+	LinkKey string
+	// Short domain of the link, the primary domain when empty
+	LinkDomain string
+	Limit      int
+	// Opaque cursor returned by the previous page
+	Cursor string
+}
+
+type HistoryResult struct {
+	// Newest revisions first
+	Revisions []LinkRevision
+	// Empty when there are no more pages
+	NextCursor string
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// LinkRevisionRepo stores the history of the links, the revisions are never updated nor deleted.
+type LinkRevisionRepo interface {
+	InsertMany(ctx context.Context, revisions []LinkRevision) error
+	// FindMany returns a page of the revisions of a key, including the revisions of the purged links which used it
+	FindMany(ctx context.Context, query HistoryQuery) (HistoryResult, error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordAttemptRepo counts the failed password attempts of each client on a protected link.
+type PasswordAttemptRepo interface {
+	// Failures returns the number of failed attempts within the current window
+	Failures(ctx context.Context, link LinkRef, clientIP string) (int, error)
+	// AddFailure counts a failed attempt, the count is reset once the window since the first failure ends
+	AddFailure(ctx context.Context, link LinkRef, clientIP string, window time.Duration) error
+}
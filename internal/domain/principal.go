@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+type Scope string
+
+const (
+	ScopeLinksCreate Scope = "links:create"
+	ScopeLinksRead   Scope = "links:read"
+	ScopeLinksUpdate Scope = "links:update"
+	ScopeLinksDelete Scope = "links:delete"
+	ScopeLinksStats  Scope = "links:stats"
+	// ScopeAdmin grants every other scope
+	ScopeAdmin Scope = "admin"
+)
+
+var AllScopes = []Scope{
+	ScopeLinksCreate,
+	ScopeLinksRead,
+	ScopeLinksUpdate,
+	ScopeLinksDelete,
+	ScopeLinksStats,
+	ScopeAdmin,
+}
+
+type AuthMethod string
+
+const (
+	AuthMethodAPIKey AuthMethod = "api_key"
+	AuthMethodJWT    AuthMethod = "jwt"
+)
+
+// Principal is the authenticated caller of a protected operation.
+type Principal struct {
+	// Owner identity: the API key ID or the subject of the token
+	Owner  string
+	Method AuthMethod
+	Scopes []Scope
+}
+
+// HasScope reports whether the principal is granted the scope, directly or through the admin scope.
+func (p Principal) HasScope(scope Scope) bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope)
+}
+
+type principalContextKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller set by the transport authentication, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+
+	return principal, ok
+}
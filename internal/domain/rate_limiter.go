@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit allows up to Requests requests per client within any Window.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Enabled reports whether the limit should be enforced, a zero limit means unlimited.
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+type RateLimitResult struct {
+	Allowed bool
+	// Time until the next request of the client is allowed, zero when allowed
+	RetryAfter time.Duration
+}
+
+// RateLimiter counts the requests of each client of a route, shared by all the instances of the service.
+type RateLimiter interface {
+	Allow(ctx context.Context, route string, client string, limit RateLimit) (RateLimitResult, error)
+}
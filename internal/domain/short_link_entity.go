@@ -1,15 +1,78 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// QueryForwarding decides how the query of a followed link is merged into the destination URL.
+type QueryForwarding string
+
+const (
+	// The query of the followed link is dropped
+	QueryForwardingNone QueryForwarding = ""
+	// The parameters are added, the destination values are kept on conflict
+	QueryForwardingKeep QueryForwarding = "keep"
+	// The parameters are added, the incoming values replace the destination ones on conflict
+	QueryForwardingOverride QueryForwarding = "override"
+	// The parameters are added, both values are kept on conflict
+	QueryForwardingAppend QueryForwarding = "append"
+)
+
+// LinkRef identifies a link, the keys are unique per short domain.
+type LinkRef struct {
+	// Empty for the primary domain
+	Domain string
+	Key    string
+}
+
+// String returns the identifier of the link in the stores keyed by a single string.
+// It is the bare key on the primary domain, so the data stored before the domains were added still matches.
+func (r LinkRef) String() string {
+	if r.Domain == "" {
+		return r.Key
+	}
+
+	return r.Domain + "/" + r.Key
+}
+
+// ParseLinkRef is the inverse of LinkRef.String, neither the domains nor the keys contain a slash.
+func ParseLinkRef(s string) LinkRef {
+	if linkDomain, key, found := strings.Cut(s, "/"); found {
+		return LinkRef{Domain: linkDomain, Key: key}
+	}
+
+	return LinkRef{Key: s}
+}
 
 type ShortLink struct {
 	// Primary key
 	ID string
-	// The unique key
+	// Short domain serving the link, empty for the primary domain
+	Domain string
+	// The key, unique per domain
 	Key         string
 	OriginalURL string
 	Hits        uint
 	CreatedAt   time.Time
+	// Zero value means the link never expires
+	ExpiresAt time.Time
+	// Maximum number of redirects, zero value means unlimited
+	MaxHits uint
+	// bcrypt hash of the password required to follow the link, empty when the link is public
+	PasswordHash string
+	// HTTP status code of the redirect, zero value means the server default
+	RedirectStatus int
+	// How the query of the followed link is forwarded
+	QueryForwarding QueryForwarding
+	// Append the path following the key to the destination path
+	ForwardPath bool
+	// Time the link was moved to the trash, zero value for the links which are not deleted
+	DeletedAt time.Time
+	// Owner of the principal who deleted the link, empty when it is unknown
+	DeletedBy string
+	// A disabled link keeps its key and stats but is not followed until it is enabled again
+	Disabled bool
 }
 
 func NewShortLink(key, originalURL string) ShortLink {
@@ -20,3 +83,27 @@ func NewShortLink(key, originalURL string) ShortLink {
 		CreatedAt:   time.Now(),
 	}
 }
+
+func (s ShortLink) Ref() LinkRef {
+	return LinkRef{Domain: s.Domain, Key: s.Key}
+}
+
+// IsExpired reports whether the link has an expiry time that is already reached.
+func (s ShortLink) IsExpired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// IsProtected reports whether the link requires a password.
+func (s ShortLink) IsProtected() bool {
+	return s.PasswordHash != ""
+}
+
+// IsExhausted reports whether the link has a hit limit that is already reached.
+func (s ShortLink) IsExhausted() bool {
+	return s.MaxHits > 0 && s.Hits >= s.MaxHits
+}
+
+// IsDeleted reports whether the link is in the trash.
+func (s ShortLink) IsDeleted() bool {
+	return !s.DeletedAt.IsZero()
+}
@@ -5,4 +5,38 @@ import "errors"
 var (
 	ErrShortLinkNotFound  = errors.New("short link not found")
 	ErrShortLinkKeyExists = errors.New("short link key exists")
+	ErrInvalidAlias       = errors.New("invalid alias")
+	ErrReservedAlias      = errors.New("reserved alias")
+	ErrShortLinkExpired   = errors.New("short link expired")
+	ErrInvalidExpiration  = errors.New("invalid expiration")
+	// ErrShortLinkExhausted is returned when the link reached its maximum number of hits
+	ErrShortLinkExhausted = errors.New("short link exhausted")
+	// ErrShortLinkDisabled is returned when a disabled link is followed or expanded
+	ErrShortLinkDisabled = errors.New("short link disabled")
+	ErrInvalidListQuery  = errors.New("invalid list query")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidStatsQuery = errors.New("invalid stats query")
+	// ErrInvalidLinkPassword is returned when a new link password is too short or too long
+	ErrInvalidLinkPassword = errors.New("invalid link password")
+	// ErrPasswordRequired is returned when a protected link is followed without a password
+	ErrPasswordRequired = errors.New("password required")
+	ErrWrongPassword    = errors.New("wrong password")
+	// ErrTooManyPasswordAttempts is returned when the client failed too many passwords recently
+	ErrTooManyPasswordAttempts = errors.New("too many password attempts")
+	// ErrInvalidRedirectStatus is returned when the redirect status is not 301, 302, 307 or 308
+	ErrInvalidRedirectStatus = errors.New("invalid redirect status")
+	// ErrInvalidQueryForwarding is returned when the query forwarding policy is unknown
+	ErrInvalidQueryForwarding = errors.New("invalid query forwarding")
+	// ErrUnknownDomain is returned when a link is requested on a domain which is not served
+	ErrUnknownDomain = errors.New("unknown domain")
+	// ErrInvalidQRCodeQuery is returned when the size, margin, level, colors or format of a QR code are invalid
+	ErrInvalidQRCodeQuery = errors.New("invalid qr code query")
+	// ErrQRCodeLogoNotConfigured is returned when a QR code asks for the logo but none is configured
+	ErrQRCodeLogoNotConfigured = errors.New("qr code logo not configured")
+	// ErrInvalidBatchSize is returned when a bulk creation is empty or has more than MaxBulkCreateItems links
+	ErrInvalidBatchSize = errors.New("invalid batch size")
+	// ErrInvalidExportQuery is returned when the creation time range of an export is empty
+	ErrInvalidExportQuery = errors.New("invalid export query")
+	// ErrInvalidPurgeAge is returned when the links to purge are selected by a negative time in the trash
+	ErrInvalidPurgeAge = errors.New("invalid purge age")
 )
@@ -4,14 +4,220 @@ import "time"
 
 type CreateAction struct {
 	OriginalURL string
+	// Optional short domain, the primary domain when empty
+	Domain string
+	// Optional custom key (vanity alias), a random key is generated when empty
+	Alias string
+	// Optional absolute expiry time, mutually exclusive with TTL
+	ExpiresAt time.Time
+	// Optional expiry relative to the creation time, mutually exclusive with ExpiresAt
+	TTL time.Duration
+	// Optional maximum number of redirects, zero value means unlimited
+	MaxHits uint
+	// Optional password required to follow the link
+	Password string
+	// Optional HTTP status code of the redirect (301, 302, 307 or 308), zero value means the server default
+	RedirectStatus int
+	// Optional forwarding of the query of the followed link
+	QueryForwarding QueryForwarding
+	// Optional forwarding of the path following the key
+	ForwardPath bool
+	// Optional creation of the link as disabled, e.g. when it is imported from an export
+	Disabled bool
+}
+
+// UpdateAction changes the mutable fields of a link, nil fields are left unchanged.
+type UpdateAction struct {
+	Key string
+	// Short domain of the link, the primary domain when empty
+	Domain      string
+	OriginalURL *string
+	// A zero time removes the expiry, mutually exclusive with TTL
+	ExpiresAt *time.Time
+	// Expiry relative to the update time, mutually exclusive with ExpiresAt
+	TTL *time.Duration
+	// A zero value removes the hit limit
+	MaxHits *uint
+	// An empty string removes the password
+	Password *string
+	// A zero value resets the redirect status to the server default
+	RedirectStatus *int
+	// QueryForwardingNone disables the query forwarding
+	QueryForwarding *QueryForwarding
+	ForwardPath     *bool
+}
+
+// RedirectRequest is a followed link.
+type RedirectRequest struct {
+	// Host the link is followed on, an unknown host is the primary domain
+	Domain string
+	Key    string
+	// Path following the key, with a leading slash, forwarded to the links with path forwarding
+	PathSuffix string
+	// Encoded query of the followed link, forwarded to the links with query forwarding
+	RawQuery string
+	// Password posted to follow a protected link
+	Password string
+	Visit    Visit
+}
+
+// PasswordAttempt is the password given to follow a protected link, with the client trying it,
+// so the failed attempts of each client can be throttled.
+type PasswordAttempt struct {
+	Password string
+	ClientIP string
 }
 
 type CreateResult struct {
 	ShortURL string
-	Key      string
+	// Empty for the primary domain
+	Domain    string
+	Key       string
+	ExpiresAt time.Time
+}
+
+// RedirectTarget is the subset of a link needed to serve a redirect.
+type RedirectTarget struct {
+	// ID of the link, the visits are recorded under it
+	LinkID          string
+	OriginalURL     string
+	ExpiresAt       time.Time
+	MaxHits         uint
+	PasswordHash    string
+	RedirectStatus  int
+	QueryForwarding QueryForwarding
+	ForwardPath     bool
+	Disabled        bool
+}
+
+// Redirect is the response to a followed link.
+type Redirect struct {
+	URL string
+	// HTTP status code, the server default is already applied
+	StatusCode int
+	// How long the client may cache the redirect, zero value means it must not be cached
+	MaxAge time.Duration
+}
+
+type StatsBucket string
+
+const (
+	StatsBucketHour StatsBucket = "hour"
+	StatsBucketDay  StatsBucket = "day"
+	StatsBucketWeek StatsBucket = "week"
+)
+
+// StatsQuery selects the stats of a link. Click analytics are computed only when
+// Bucket is set, over the [From, To) range.
+type StatsQuery struct {
+	Key string
+	// Short domain of the link, the primary domain when empty
+	Domain string
+	Bucket StatsBucket
+	// Defaults to a range ending now, sized by the bucket
+	From time.Time
+	To   time.Time
+	// Number of entries in the top lists
+	TopLimit int
 }
 
 type StatsResult struct {
-	Hits      uint
-	CreatedAt time.Time
+	// ID of the link, the visits are recorded under it
+	LinkID string
+	// Human redirects, bot redirects are counted in BotHits
+	Hits    uint
+	BotHits uint
+	// Estimated number of distinct visitors, based on the IP address and user agent
+	UniqueVisitors uint
+	MaxHits        uint
+	CreatedAt      time.Time
+	// Click analytics, empty unless requested
+	Analytics ClickAnalytics
+}
+
+type ListSortField string
+
+const (
+	ListSortByCreatedAt ListSortField = "createdAt"
+	ListSortByHits      ListSortField = "hits"
+)
+
+// ListQuery filters and pages through the links, zero value filters are ignored.
+type ListQuery struct {
+	// Destination host, compared case-insensitively
+	Host          string
+	KeyPrefix     string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Defaults to ListSortByCreatedAt
+	SortBy    ListSortField
+	Ascending bool
+	Limit     int
+	// Opaque cursor returned by the previous page
+	Cursor string
+	// List the links of the trash instead of the active ones
+	Deleted bool
+}
+
+type ListResult struct {
+	Links []ShortLink
+	// Empty when there are no more pages
+	NextCursor string
+}
+
+// ExportQuery filters the exported links, the zero value exports every link.
+type ExportQuery struct {
+	// Short domain of the links, every domain when empty
+	Domain        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// ExportFilter is the ExportQuery passed to the repository, with the domain in its stored form.
+type ExportFilter struct {
+	// nil matches every domain, the empty string only the primary one
+	Domain        *string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+type QRCodeFormat string
+
+const (
+	QRCodeFormatPNG QRCodeFormat = "png"
+	QRCodeFormatSVG QRCodeFormat = "svg"
+)
+
+// QRCodeQuery selects a link and how its QR code is drawn, zero values use the defaults.
+type QRCodeQuery struct {
+	Key string
+	// Short domain of the link, the primary domain when empty
+	Domain string
+	// Defaults to QRCodeFormatPNG
+	Format QRCodeFormat
+	// Width and height of the image, in pixels
+	Size int
+	// Quiet zone around the code, in modules, nil means the default
+	Margin *int
+	// Error correction level: L, M, Q or H
+	Level string
+	// Hex colors like "#1a2b3c"
+	Foreground string
+	Background string
+	// Embed the configured logo in the middle of the code
+	Logo bool
+}
+
+type QRCode struct {
+	ContentType string
+	Data        []byte
+}
+
+// MaxBulkCreateItems is the largest number of links created by one bulk creation.
+const MaxBulkCreateItems = 1000
+
+// BulkCreateResult is the outcome of one link of a bulk creation, the created link or its error.
+type BulkCreateResult struct {
+	CreateResult
+	Err error
 }
@@ -2,13 +2,39 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 type ShortLinkRepo interface {
 	InsertOne(ctx context.Context, shortLink ShortLink) (string, error)
-	FindOne(ctx context.Context, key string) (ShortLink, error)
-	FindOriginalURL(ctx context.Context, key string) (string, error)
-	DeleteOne(ctx context.Context, key string) error
-	IncreaseHits(ctx context.Context, key string) error
-	FindStats(ctx context.Context, key string) (StatsResult, error)
+	// InsertMany inserts the links in one call and returns the error of each link, nil for the inserted ones.
+	// A taken key is reported as ErrShortLinkKeyExists and does not stop the insertion of the other links.
+	InsertMany(ctx context.Context, shortLinks []ShortLink) ([]error, error)
+	FindOne(ctx context.Context, link LinkRef) (ShortLink, error)
+	// FindRedirectTarget returns the data of an active link, expired and exhausted links are reported as errors.
+	// The disabled links are returned, so their flag is cached with the rest of the target
+	FindRedirectTarget(ctx context.Context, link LinkRef) (RedirectTarget, error)
+	// FindMany returns a page of links sorted and filtered by the query
+	FindMany(ctx context.Context, query ListQuery) (ListResult, error)
+	// Export calls fn for each link matching the filter, oldest first, without holding them in memory.
+	// An error of fn stops the export and is returned.
+	Export(ctx context.Context, filter ExportFilter, fn func(ShortLink) error) error
+	// UpdateOne replaces the mutable fields of the link and returns the updated link
+	UpdateOne(ctx context.Context, shortLink ShortLink) (ShortLink, error)
+	// DeleteOne moves the link to the trash and returns the deleted link, its key stays taken until the link is purged.
+	// The links of the trash are reported as ErrShortLinkNotFound by the other methods
+	DeleteOne(ctx context.Context, link LinkRef, deletedBy string) (ShortLink, error)
+	// RestoreOne moves the link out of the trash and returns the link as it was in the trash
+	RestoreOne(ctx context.Context, link LinkRef) (ShortLink, error)
+	// SetDisabled disables or enables the link and returns the link as it was before the change
+	SetDisabled(ctx context.Context, link LinkRef, disabled bool) (ShortLink, error)
+	// PurgeOne permanently removes a link of the trash
+	PurgeOne(ctx context.Context, link LinkRef) error
+	// PurgeDeleted permanently removes the links moved to the trash before the time and returns their number
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// IncreaseHits counts a redirect, limited links are always counted synchronously to enforce the limit
+	IncreaseHits(ctx context.Context, link LinkRef, limited bool) error
+	// IncreaseBotHits counts a redirect made by a bot, it does not count against the hit limit
+	IncreaseBotHits(ctx context.Context, link LinkRef) error
+	FindStats(ctx context.Context, link LinkRef) (StatsResult, error)
 }
@@ -1,11 +1,44 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ShortLinkUsecase interface {
 	Create(ctx context.Context, createAction CreateAction) (CreateResult, error)
-	Expand(ctx context.Context, key string) (ShortLink, error)
-	OriginalURL(ctx context.Context, key string) (string, error)
-	Delete(ctx context.Context, key string) error
-	Stats(ctx context.Context, key string) (StatsResult, error)
+	// CreateMany creates up to MaxBulkCreateItems links, the results follow the order of the actions.
+	// The invalid links and the taken aliases are reported in their results without failing the others.
+	CreateMany(ctx context.Context, createActions []CreateAction) ([]BulkCreateResult, error)
+	// Expand resolves the link, the password of the attempt is checked when the link is protected
+	Expand(ctx context.Context, link LinkRef, attempt PasswordAttempt) (ShortLink, error)
+	// Preview is the Expand of a link followed on the host of its domain,
+	// the unknown hosts are served as the primary domain like in Redirect
+	Preview(ctx context.Context, link LinkRef, attempt PasswordAttempt) (ShortLink, error)
+	// Redirect resolves the link for a redirect and records the visit,
+	// the password is checked when the link is protected
+	Redirect(ctx context.Context, request RedirectRequest) (Redirect, error)
+	Update(ctx context.Context, updateAction UpdateAction) (ShortLink, error)
+	// Delete moves the link to the trash, it can be restored until it is purged
+	Delete(ctx context.Context, link LinkRef) error
+	// Restore moves the link out of the trash and returns it
+	Restore(ctx context.Context, link LinkRef) (ShortLink, error)
+	// Disable pauses the link without deleting it and returns it, the link keeps its key and stats
+	Disable(ctx context.Context, link LinkRef) (ShortLink, error)
+	// Enable resumes a disabled link and returns it
+	Enable(ctx context.Context, link LinkRef) (ShortLink, error)
+	// Purge permanently removes a link of the trash
+	Purge(ctx context.Context, link LinkRef) error
+	// PurgeDeleted permanently removes the links which are in the trash for longer than olderThan
+	// and returns their number
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error)
+	Stats(ctx context.Context, query StatsQuery) (StatsResult, error)
+	List(ctx context.Context, query ListQuery) (ListResult, error)
+	Clicks(ctx context.Context, query ClickQuery) (ClickResult, error)
+	// History returns the revisions of the link, newest first, the links of the trash keep their history
+	History(ctx context.Context, query HistoryQuery) (HistoryResult, error)
+	// Export calls fn for each link matching the query, oldest first, an error of fn stops the export
+	Export(ctx context.Context, query ExportQuery, fn func(ShortLink) error) error
+	// QRCode draws the QR code of the short URL of the link
+	QRCode(ctx context.Context, query QRCodeQuery) (QRCode, error)
 }
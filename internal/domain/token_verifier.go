@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// TokenClaims are the verified claims of a JWT which matter for authorization.
+type TokenClaims struct {
+	Subject string
+	// Values of the scope claim, including the ones unknown to the service
+	Scopes []string
+}
+
+// TokenVerifier validates the signature, issuer, audience and expiry of a JWT.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (TokenClaims, error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UniqueVisitorRepo estimates the number of distinct visitors of a link.
+// The visitors are kept by the ID of the link, so a reused key starts without the visitors of the previous link.
+type UniqueVisitorRepo interface {
+	Add(ctx context.Context, linkID string, visitorID string, at time.Time) error
+	// Count returns the estimated number of distinct visitors between the two times, inclusive by day
+	Count(ctx context.Context, linkID string, from time.Time, to time.Time) (uint, error)
+}
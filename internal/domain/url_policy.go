@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+)
+
+// URLPolicy decides whether a destination URL may be shortened.
+// The URL passed to Check is already validated as an absolute http(s) URL,
+// originalURL is the URL as given and stored, destination is its parsed form.
+type URLPolicy interface {
+	Check(ctx context.Context, originalURL string, destination *url.URL) error
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+var (
+	ErrURLTooLong = errors.New("URL too long")
+	// ErrDomainNotAllowed is returned when the URL host is denied by the domain rules
+	ErrDomainNotAllowed = errors.New("domain not allowed")
+	// ErrPrivateAddress is returned when the URL host is a loopback, private or link-local address
+	ErrPrivateAddress = errors.New("private network address")
+	// ErrShortenerURL is returned when the URL points back at this service or another URL shortener
+	ErrShortenerURL = errors.New("URL of a shortener")
+)
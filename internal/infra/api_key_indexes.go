@@ -0,0 +1,26 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func EnsureAPIKeyIndexes(ctx context.Context, logger *slog.Logger, mongoClient *mongo.Client) error {
+	collection := mongoClient.Database(shortenerDBName).Collection(apiKeysCollectionName)
+
+	return ensureIndexes(ctx, logger, collection, []indexSpec{
+		{
+			// every request authenticated by a key looks it up by hash
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "hash", Value: 1}},
+				Options: options.Index().SetName(apiKeysHashUniqueIndexName).SetUnique(true),
+			},
+			name:      apiKeysHashUniqueIndexName,
+			isPresent: hasIndexName(apiKeysHashUniqueIndexName),
+		},
+	})
+}
@@ -0,0 +1,159 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	apiKeysCollectionName      = "api_keys"
+	apiKeysHashUniqueIndexName = "api_keys_hash_unique"
+)
+
+type apiKeyDoc struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty"`
+	Name       string              `bson:"name"`
+	Prefix     string              `bson:"prefix"`
+	Hash       string              `bson:"hash"`
+	Scopes     []string            `bson:"scopes"`
+	CreatedAt  primitive.DateTime  `bson:"createdAt"`
+	LastUsedAt *primitive.DateTime `bson:"lastUsedAt,omitempty"`
+	RevokedAt  *primitive.DateTime `bson:"revokedAt,omitempty"`
+}
+
+var _ domain.APIKeyRepo = (*apiKeyRepo)(nil)
+
+type apiKeyRepo struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewAPIKeyRepository(logger *slog.Logger, mongo *mongo.Client) *apiKeyRepo {
+	return &apiKeyRepo{
+		logger:     logger,
+		collection: mongo.Database(shortenerDBName).Collection(apiKeysCollectionName),
+	}
+}
+
+func (r *apiKeyRepo) InsertOne(ctx context.Context, key domain.APIKey) (string, error) {
+	res, err := r.collection.InsertOne(ctx, fromAPIKeyToDocument(key))
+	if err != nil {
+		return "", fmt.Errorf("insert api key: %w", err)
+	}
+
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (r *apiKeyRepo) FindByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	doc := new(apiKeyDoc)
+	err := r.collection.FindOne(ctx, bson.M{"hash": hash}).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	return fromDocumentToAPIKey(*doc), nil
+}
+
+func (r *apiKeyRepo) FindAll(ctx context.Context) ([]domain.APIKey, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("find api keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []apiKeyDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode api keys: %w", err)
+	}
+
+	keys := make([]domain.APIKey, 0, len(docs))
+	for _, doc := range docs {
+		keys = append(keys, fromDocumentToAPIKey(doc))
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepo) Revoke(ctx context.Context, id string, at time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	// revoking a revoked key keeps the original revocation time
+	filter := bson.M{"_id": objectID}
+	update := bson.A{bson.M{"$set": bson.M{"revokedAt": bson.M{"$ifNull": bson.A{"$revokedAt", primitive.NewDateTimeFromTime(at)}}}}}
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepo) UpdateLastUsed(ctx context.Context, id string, at time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	_, err = r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{"lastUsedAt": primitive.NewDateTimeFromTime(at)}},
+	)
+	if err != nil {
+		return fmt.Errorf("update api key last use: %w", err)
+	}
+
+	return nil
+}
+
+func fromAPIKeyToDocument(key domain.APIKey) apiKeyDoc {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return apiKeyDoc{
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Hash:       key.Hash,
+		Scopes:     scopes,
+		CreatedAt:  primitive.NewDateTimeFromTime(key.CreatedAt),
+		LastUsedAt: toOptionalDateTime(key.LastUsedAt),
+		RevokedAt:  toOptionalDateTime(key.RevokedAt),
+	}
+}
+
+func fromDocumentToAPIKey(doc apiKeyDoc) domain.APIKey {
+	scopes := make([]domain.Scope, 0, len(doc.Scopes))
+	for _, scope := range doc.Scopes {
+		scopes = append(scopes, domain.Scope(scope))
+	}
+
+	return domain.APIKey{
+		ID:         doc.ID.Hex(),
+		Name:       doc.Name,
+		Prefix:     doc.Prefix,
+		Hash:       doc.Hash,
+		Scopes:     scopes,
+		CreatedAt:  doc.CreatedAt.Time(),
+		LastUsedAt: fromOptionalDateTime(doc.LastUsedAt),
+		RevokedAt:  fromOptionalDateTime(doc.RevokedAt),
+	}
+}
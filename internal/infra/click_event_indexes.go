@@ -0,0 +1,35 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func EnsureClickEventIndexes(ctx context.Context, logger *slog.Logger, mongoClient *mongo.Client) error {
+	collection := mongoClient.Database(shortenerDBName).Collection(clickEventsCollectionName)
+
+	return ensureIndexes(ctx, logger, collection, []indexSpec{
+		{
+			// events of a link are paged newest first, "_id" is the tie-breaker for the cursor
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "linkKey", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}},
+				Options: options.Index().SetName(clickEventsLinkKeyTSIndexName),
+			},
+			name:      clickEventsLinkKeyTSIndexName,
+			isPresent: hasIndexName(clickEventsLinkKeyTSIndexName),
+		},
+		{
+			// the events are recorded under the ID of the link, the key index serves the events recorded before
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "linkId", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}},
+				Options: options.Index().SetName(clickEventsLinkIDTSIndexName),
+			},
+			name:      clickEventsLinkIDTSIndexName,
+			isPresent: hasIndexName(clickEventsLinkIDTSIndexName),
+		},
+	})
+}
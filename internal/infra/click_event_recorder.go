@@ -0,0 +1,124 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	clickRecorderBufferSize    = 4096
+	clickRecorderBatchSize     = 256
+	clickRecorderFlushInterval = time.Second
+	clickRecorderWriteTimeout  = 5 * time.Second
+)
+
+var _ domain.ClickRecorder = (*ClickEventRecorder)(nil)
+
+// ClickEventRecorder collects click events in a buffered channel and writes them
+// in batches from a background goroutine. Events are dropped when the buffer is full.
+type ClickEventRecorder struct {
+	logger *slog.Logger
+	repo   domain.ClickEventRepo
+	events chan domain.ClickEvent
+	done   chan struct{}
+	// guards events against sends after the channel is closed
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewClickEventRecorder(logger *slog.Logger, repo domain.ClickEventRepo) *ClickEventRecorder {
+	r := &ClickEventRecorder{
+		logger: logger,
+		repo:   repo,
+		events: make(chan domain.ClickEvent, clickRecorderBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r
+}
+
+// Record queues the event without blocking the caller.
+func (r *ClickEventRecorder) Record(event domain.ClickEvent) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		r.logger.Warn("click event buffer is full, dropping event",
+			slog.String("key", event.LinkKey),
+		)
+	}
+}
+
+// Close stops accepting events and waits until the queued events are written.
+func (r *ClickEventRecorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *ClickEventRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(clickRecorderFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.ClickEvent, 0, clickRecorderBatchSize)
+
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+
+			batch = append(batch, event)
+			if len(batch) >= clickRecorderBatchSize {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+
+		case <-ticker.C:
+			r.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (r *ClickEventRecorder) flush(batch []domain.ClickEvent) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clickRecorderWriteTimeout)
+	defer cancel()
+
+	if err := r.repo.InsertMany(ctx, batch); err != nil {
+		r.logger.Error("unable to store click events",
+			slog.Int("count", len(batch)),
+			slog.Any("error", err),
+		)
+	}
+}
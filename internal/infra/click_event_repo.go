@@ -0,0 +1,312 @@
+package infra
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/uafamily"
+)
+
+const (
+	clickEventsCollectionName     = "click_events"
+	clickEventsLinkKeyTSIndexName = "click_events_link_key_timestamp"
+	clickEventsLinkIDTSIndexName  = "click_events_link_id_timestamp"
+)
+
+type clickEventDoc struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	LinkID         primitive.ObjectID `bson:"linkId,omitempty"` // missing on the events recorded before it was added
+	LinkKey        string             `bson:"linkKey"`
+	Timestamp      primitive.DateTime `bson:"timestamp"`
+	Referrer       string             `bson:"referrer,omitempty"`
+	UserAgent      string             `bson:"userAgent,omitempty"`
+	IP             string             `bson:"ip,omitempty"`
+	AcceptLanguage string             `bson:"acceptLanguage,omitempty"`
+	Country        string             `bson:"country,omitempty"`
+	Purpose        string             `bson:"purpose,omitempty"`
+	Bot            bool               `bson:"bot,omitempty"`
+	// denormalized for the aggregations
+	ReferrerHost string `bson:"referrerHost,omitempty"`
+	UAFamily     string `bson:"uaFamily,omitempty"`
+}
+
+type statsPointDoc struct {
+	Start     primitive.DateTime `bson:"_id"`
+	Clicks    uint               `bson:"clicks"`
+	BotClicks uint               `bson:"botClicks"`
+}
+
+type statsCountDoc struct {
+	Value  string `bson:"_id"`
+	Clicks uint   `bson:"clicks"`
+}
+
+type clickAnalyticsDoc struct {
+	Timeline   []statsPointDoc `bson:"timeline"`
+	Referrers  []statsCountDoc `bson:"referrers"`
+	Countries  []statsCountDoc `bson:"countries"`
+	UserAgents []statsCountDoc `bson:"userAgents"`
+}
+
+// clickCursor is the position of the last returned event, encoded into an opaque string.
+type clickCursor struct {
+	Timestamp int64  `json:"t"`
+	ID        string `json:"i"`
+}
+
+var _ domain.ClickEventRepo = (*clickEventRepo)(nil)
+
+type clickEventRepo struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewClickEventRepository(logger *slog.Logger, mongo *mongo.Client) *clickEventRepo {
+	return &clickEventRepo{
+		logger:     logger,
+		collection: mongo.Database(shortenerDBName).Collection(clickEventsCollectionName),
+	}
+}
+
+func (r *clickEventRepo) InsertMany(ctx context.Context, events []domain.ClickEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(events))
+	for _, event := range events {
+		docs = append(docs, fromClickEventToDocument(event))
+	}
+
+	// unordered, so a single bad document does not prevent storing the others
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("insert click events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *clickEventRepo) FindMany(ctx context.Context, query domain.ClickQuery) (domain.ClickResult, error) {
+	filter := clickLinkFilter(query.LinkID, domain.LinkRef{Domain: query.LinkDomain, Key: query.LinkKey}, query.LinkCreatedAt)
+
+	if query.Cursor != "" {
+		ts, id, err := decodeClickCursor(query.Cursor)
+		if err != nil {
+			return domain.ClickResult{}, err
+		}
+
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{"timestamp": bson.M{"$lt": ts}},
+			bson.M{"timestamp": ts, "_id": bson.M{"$lt": id}},
+		}}}}
+	}
+
+	// one extra document is fetched to know if there is a next page
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(query.Limit) + 1)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.ClickResult{}, fmt.Errorf("find click events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := make([]clickEventDoc, 0, query.Limit+1)
+	if err := cursor.All(ctx, &docs); err != nil {
+		return domain.ClickResult{}, fmt.Errorf("decode click events: %w", err)
+	}
+
+	result := domain.ClickResult{}
+	if len(docs) > query.Limit {
+		docs = docs[:query.Limit]
+		result.NextCursor = encodeClickCursor(docs[len(docs)-1])
+	}
+
+	result.Events = make([]domain.ClickEvent, 0, len(docs))
+	for _, doc := range docs {
+		result.Events = append(result.Events, fromDocumentToClickEvent(doc))
+	}
+
+	return result, nil
+}
+
+func (r *clickEventRepo) Aggregate(ctx context.Context, query domain.ClickAnalyticsQuery) (domain.ClickAnalytics, error) {
+	dateTrunc := bson.M{"date": "$timestamp", "unit": string(query.Bucket), "timezone": "UTC"}
+	if query.Bucket == domain.StatsBucketWeek {
+		dateTrunc["startOfWeek"] = "monday"
+	}
+
+	// the top lists describe human traffic only
+	topValues := func(field string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{field: bson.M{"$nin": bson.A{"", nil}}, "bot": bson.M{"$ne": true}}},
+			bson.M{"$group": bson.M{"_id": "$" + field, "clicks": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "clicks", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": query.TopLimit},
+		}
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"$and": bson.A{
+			clickLinkFilter(query.LinkID, domain.LinkRef{Domain: query.LinkDomain, Key: query.LinkKey}, query.LinkCreatedAt),
+			bson.M{"timestamp": bson.M{
+				"$gte": primitive.NewDateTimeFromTime(query.From),
+				"$lt":  primitive.NewDateTimeFromTime(query.To),
+			}},
+		}}},
+		bson.M{"$facet": bson.M{
+			"timeline": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":       bson.M{"$dateTrunc": dateTrunc},
+					"clicks":    bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$bot", true}}, 0, 1}}},
+					"botClicks": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$bot", true}}, 1, 0}}},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+			"referrers":  topValues("referrerHost"),
+			"countries":  topValues("country"),
+			"userAgents": topValues("uaFamily"),
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return domain.ClickAnalytics{}, fmt.Errorf("aggregate click events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	// $facet always outputs a single document
+	doc := new(clickAnalyticsDoc)
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(doc); err != nil {
+			return domain.ClickAnalytics{}, fmt.Errorf("decode click analytics: %w", err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return domain.ClickAnalytics{}, fmt.Errorf("iterate click analytics: %w", err)
+	}
+
+	analytics := domain.ClickAnalytics{
+		Timeline:      make([]domain.StatsPoint, 0, len(doc.Timeline)),
+		TopReferrers:  fromStatsCountDocs(doc.Referrers),
+		TopCountries:  fromStatsCountDocs(doc.Countries),
+		TopUserAgents: fromStatsCountDocs(doc.UserAgents),
+	}
+	for _, p := range doc.Timeline {
+		analytics.Timeline = append(analytics.Timeline, domain.StatsPoint{
+			Start:     p.Start.Time().UTC(),
+			Clicks:    p.Clicks,
+			BotClicks: p.BotClicks,
+		})
+	}
+
+	return analytics, nil
+}
+
+// clickLinkFilter matches the events of the link by its ID. The events recorded before the ID was stored with them
+// are matched by the key from the creation of the link, so a reused key does not inherit them either.
+func clickLinkFilter(linkID string, link domain.LinkRef, createdAt time.Time) bson.M {
+	// the links always have an ObjectID, a malformed one matches no event
+	id, _ := primitive.ObjectIDFromHex(linkID)
+
+	return bson.M{"$or": bson.A{
+		bson.M{"linkId": id},
+		bson.M{
+			"linkId":    bson.M{"$exists": false},
+			"linkKey":   link.String(),
+			"timestamp": bson.M{"$gte": primitive.NewDateTimeFromTime(createdAt)},
+		},
+	}}
+}
+
+func fromStatsCountDocs(docs []statsCountDoc) []domain.StatsCount {
+	counts := make([]domain.StatsCount, 0, len(docs))
+	for _, d := range docs {
+		counts = append(counts, domain.StatsCount{Value: d.Value, Clicks: d.Clicks})
+	}
+
+	return counts
+}
+
+func encodeClickCursor(lastDoc clickEventDoc) string {
+	data, _ := json.Marshal(clickCursor{
+		Timestamp: int64(lastDoc.Timestamp),
+		ID:        lastDoc.ID.Hex(),
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeClickCursor(cursor string) (primitive.DateTime, primitive.ObjectID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+
+	var c clickCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return 0, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return 0, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+
+	return primitive.DateTime(c.Timestamp), id, nil
+}
+
+func fromClickEventToDocument(event domain.ClickEvent) clickEventDoc {
+	// the links always have an ObjectID, a malformed one is left out rather than losing the event
+	linkID, _ := primitive.ObjectIDFromHex(event.LinkID)
+
+	return clickEventDoc{
+		LinkID:         linkID,
+		LinkKey:        event.LinkKey,
+		Timestamp:      primitive.NewDateTimeFromTime(event.Timestamp),
+		Referrer:       event.Referrer,
+		UserAgent:      event.UserAgent,
+		IP:             event.IP,
+		AcceptLanguage: event.AcceptLanguage,
+		Country:        strings.ToUpper(event.Country),
+		Purpose:        event.Purpose,
+		Bot:            event.Bot,
+		ReferrerHost:   extractHost(event.Referrer),
+		UAFamily:       uafamily.Family(event.UserAgent),
+	}
+}
+
+func fromDocumentToClickEvent(doc clickEventDoc) domain.ClickEvent {
+	event := domain.ClickEvent{
+		ID:        doc.ID.Hex(),
+		LinkKey:   doc.LinkKey,
+		Timestamp: doc.Timestamp.Time(),
+		Bot:       doc.Bot,
+		Visit: domain.Visit{
+			Referrer:       doc.Referrer,
+			UserAgent:      doc.UserAgent,
+			IP:             doc.IP,
+			AcceptLanguage: doc.AcceptLanguage,
+			Country:        doc.Country,
+			Purpose:        doc.Purpose,
+		},
+	}
+	if !doc.LinkID.IsZero() {
+		event.LinkID = doc.LinkID.Hex()
+	}
+
+	return event
+}
@@ -0,0 +1,375 @@
+package infra
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	// a token signed by an unknown key triggers a refresh at most once per interval
+	jwksMinRefreshInterval = time.Minute
+	// the keys fetched from a URL are refreshed after this age, so removed keys stop being accepted
+	jwksMaxAge         = time.Hour
+	jwksFetchTimeout   = 5 * time.Second
+	jwksMaxBodySize    = 1 << 20
+	jwtClockSkewLeeway = 30 * time.Second
+)
+
+// errJWKSUnavailable marks the failures to obtain the keys, as opposed to invalid tokens.
+var errJWKSUnavailable = errors.New("jwks is unavailable")
+
+var _ domain.TokenVerifier = (*jwtVerifier)(nil)
+
+// JWTVerifierOptions describes where the signing keys are loaded from and which claims are expected.
+type JWTVerifierOptions struct {
+	// Path of a JWKS file, takes precedence over JWKSURL
+	JWKSFile   string
+	JWKSURL    string
+	Issuer     string
+	Audience   string
+	ScopeClaim string
+	OwnerClaim string
+}
+
+// jwtVerifier validates the JWTs with the public keys of a JWKS.
+// A JWKS file is read once at startup, a JWKS URL is fetched lazily and refreshed on key rotation.
+type jwtVerifier struct {
+	logger     *slog.Logger
+	options    JWTVerifierOptions
+	parser     *jwt.Parser
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	// the last attempt to fetch the JWKS URL, successful or not
+	attemptedAt  time.Time
+	refreshGroup singleflight.Group
+}
+
+func NewJWTVerifier(logger *slog.Logger, options JWTVerifierOptions) (*jwtVerifier, error) {
+	v := &jwtVerifier{
+		logger:  logger,
+		options: options,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+			jwt.WithIssuer(options.Issuer),
+			jwt.WithAudience(options.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(jwtClockSkewLeeway),
+		),
+		httpClient: &http.Client{Timeout: jwksFetchTimeout},
+	}
+
+	if options.JWKSFile != "" {
+		data, err := os.ReadFile(options.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks file: %w", err)
+		}
+
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse jwks file: %w", err)
+		}
+		v.keys = keys
+	} else if options.JWKSURL == "" {
+		return nil, fmt.Errorf("either a jwks file or a jwks url is required")
+	}
+
+	return v, nil
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (domain.TokenClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.findKey(ctx, kid)
+	})
+	if err != nil {
+		if errors.Is(err, errJWKSUnavailable) {
+			return domain.TokenClaims{}, err
+		}
+
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	owner, _ := claims[v.options.OwnerClaim].(string)
+
+	return domain.TokenClaims{
+		Subject: owner,
+		Scopes:  parseScopeClaim(claims[v.options.ScopeClaim]),
+	}, nil
+}
+
+// findKey returns the key identified by kid, or the only key of the set when the token has no kid.
+func (v *jwtVerifier) findKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if v.options.JWKSFile == "" {
+		if err := v.refreshIfNeeded(ctx, kid); err != nil {
+			return nil, err
+		}
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if kid == "" {
+		if len(v.keys) != 1 {
+			return nil, fmt.Errorf("token has no kid and the jwks has %d keys", len(v.keys))
+		}
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+
+	return key, nil
+}
+
+func (v *jwtVerifier) refreshIfNeeded(ctx context.Context, kid string) error {
+	v.mu.RLock()
+	_, known := v.keys[kid]
+	if kid == "" {
+		known = len(v.keys) > 0
+	}
+	stale := time.Since(v.fetchedAt) >= jwksMaxAge
+	v.mu.RUnlock()
+
+	if known && !stale {
+		return nil
+	}
+
+	// the concurrent requests share a single fetch, which runs without holding the lock
+	// so the tokens signed by the known keys are verified meanwhile
+	_, err, _ := v.refreshGroup.Do("jwks", func() (any, error) {
+		return nil, v.refresh(context.WithoutCancel(ctx))
+	})
+
+	return err
+}
+
+func (v *jwtVerifier) refresh(ctx context.Context) error {
+	v.mu.Lock()
+	// the provider must not be flooded by the tokens with made-up kids
+	if time.Since(v.attemptedAt) < jwksMinRefreshInterval {
+		loaded := v.keys != nil
+		v.mu.Unlock()
+
+		if !loaded {
+			return errJWKSUnavailable
+		}
+		return nil
+	}
+	v.attemptedAt = time.Now()
+	v.mu.Unlock()
+
+	keys, err := v.fetchJWKS(ctx)
+	if err != nil {
+		v.logger.Error("unable to fetch the jwks",
+			slog.String("url", v.options.JWKSURL),
+			slog.Any("error", err),
+		)
+
+		v.mu.RLock()
+		loaded := v.keys != nil
+		v.mu.RUnlock()
+
+		// the previous keys remain valid until the provider is reachable again
+		if !loaded {
+			return fmt.Errorf("%w: %w", errJWKSUnavailable, err)
+		}
+		return nil
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+func (v *jwtVerifier) fetchJWKS(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.options.JWKSURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	return parseJWKS(data)
+}
+
+// jsonWebKey holds the members of RFC 7517 keys used for signature verification.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS decodes the signature keys of a JWKS. The keys of unsupported types are skipped.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks has no signature keys")
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode n: %w", err)
+		}
+		e, err := decodeJWKBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode e: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent is too large")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+
+		point := append([]byte{4}, x...)
+		point = append(point, y...)
+
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, nil
+}
+
+func decodeJWKBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("value is empty")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+// parseScopeClaim accepts both the space separated string of RFC 8693 and an array of strings.
+func parseScopeClaim(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return strings.Fields(value)
+
+	case []any:
+		scopes := make([]string, 0, len(value))
+		for _, item := range value {
+			if scope, ok := item.(string); ok {
+				scopes = append(scopes, scope)
+			}
+		}
+		return scopes
+	}
+
+	return nil
+}
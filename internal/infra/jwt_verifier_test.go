@@ -0,0 +1,209 @@
+package infra_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/internal/infra"
+)
+
+func marshalJWKS(t *testing.T, kid string, key *rsa.PublicKey) []byte {
+	t.Helper()
+
+	jwks := map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+
+	return data
+}
+
+func writeJWKSFile(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	data := marshalJWKS(t, kid, key)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+
+	return path
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return signed
+}
+
+func TestJWTVerifierWithJWKSFile(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	verifier, err := infra.NewJWTVerifier(slog.New(slog.NewTextHandler(io.Discard, nil)), infra.JWTVerifierOptions{
+		JWKSFile:   writeJWKSFile(t, "test-key", &signingKey.PublicKey),
+		Issuer:     "https://issuer.example.com",
+		Audience:   "url-shortener",
+		ScopeClaim: "scope",
+		OwnerClaim: "sub",
+	})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() error = %v", err)
+	}
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   "https://issuer.example.com",
+			"aud":   "url-shortener",
+			"sub":   "user-1",
+			"scope": "links:create links:read",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		key            *rsa.PrivateKey
+		kid            string
+		modify         func(jwt.MapClaims)
+		expectedScopes []string
+		expectedErr    error
+	}{
+		{name: "Valid Token", key: signingKey, kid: "test-key", modify: func(jwt.MapClaims) {}, expectedScopes: []string{"links:create", "links:read"}},
+		{name: "Scope Array", key: signingKey, kid: "test-key", modify: func(c jwt.MapClaims) { c["scope"] = []string{"links:stats"} }, expectedScopes: []string{"links:stats"}},
+		{name: "Wrong Issuer", key: signingKey, kid: "test-key", modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, expectedErr: domain.ErrInvalidToken},
+		{name: "Wrong Audience", key: signingKey, kid: "test-key", modify: func(c jwt.MapClaims) { c["aud"] = "another-service" }, expectedErr: domain.ErrInvalidToken},
+		{name: "Expired", key: signingKey, kid: "test-key", modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, expectedErr: domain.ErrInvalidToken},
+		{name: "Missing Expiry", key: signingKey, kid: "test-key", modify: func(c jwt.MapClaims) { delete(c, "exp") }, expectedErr: domain.ErrInvalidToken},
+		{name: "Unknown Key ID", key: signingKey, kid: "rotated-key", modify: func(jwt.MapClaims) {}, expectedErr: domain.ErrInvalidToken},
+		{name: "Bad Signature", key: otherKey, kid: "test-key", modify: func(jwt.MapClaims) {}, expectedErr: domain.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.modify(claims)
+
+			got, err := verifier.Verify(context.Background(), signToken(t, tt.key, tt.kid, claims))
+			if err != tt.expectedErr {
+				t.Fatalf("Verify() error = %v; want %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Subject != "user-1" {
+				t.Errorf("Verify() subject = %q; want %q", got.Subject, "user-1")
+			}
+			if !slices.Equal(got.Scopes, tt.expectedScopes) {
+				t.Errorf("Verify() scopes = %v; want %v", got.Scopes, tt.expectedScopes)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierWithJWKSURL(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	jwks := marshalJWKS(t, "test-key", &signingKey.PublicKey)
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		w.Write(jwks)
+	}))
+	defer server.Close()
+
+	verifier, err := infra.NewJWTVerifier(slog.New(slog.NewTextHandler(io.Discard, nil)), infra.JWTVerifierOptions{
+		JWKSURL:    server.URL,
+		Issuer:     "https://issuer.example.com",
+		Audience:   "url-shortener",
+		ScopeClaim: "scope",
+		OwnerClaim: "sub",
+	})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() error = %v", err)
+	}
+
+	token := signToken(t, signingKey, "test-key", jwt.MapClaims{
+		"iss": "https://issuer.example.com",
+		"aud": "url-shortener",
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	// the requests arriving while the keys are fetched wait for the same fetch
+	const requests = 10
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.Verify(context.Background(), token)
+			errs <- err
+		}()
+	}
+
+	for fetches.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Verify() error = %v; want nil", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("jwks fetched %d times; want 1", got)
+	}
+}
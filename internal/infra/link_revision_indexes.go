@@ -0,0 +1,26 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func EnsureLinkRevisionIndexes(ctx context.Context, logger *slog.Logger, mongoClient *mongo.Client) error {
+	collection := mongoClient.Database(shortenerDBName).Collection(linkRevisionsCollectionName)
+
+	return ensureIndexes(ctx, logger, collection, []indexSpec{
+		{
+			// revisions of a key are paged newest first, "_id" is the tie-breaker for the cursor
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "key", Value: 1}, {Key: "domain", Value: 1}, {Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}},
+				Options: options.Index().SetName(linkRevisionsKeyCreatedAtIndexName),
+			},
+			name:      linkRevisionsKeyCreatedAtIndexName,
+			isPresent: hasIndexName(linkRevisionsKeyCreatedAtIndexName),
+		},
+	})
+}
@@ -0,0 +1,207 @@
+package infra
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	linkRevisionsCollectionName        = "link_revisions"
+	linkRevisionsKeyCreatedAtIndexName = "link_revisions_key_domain_created_at"
+)
+
+type linkRevisionDoc struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	LinkID primitive.ObjectID `bson:"linkId,omitempty"`
+	// the field is omitted for links on the primary domain, like in the links collection
+	Domain    string             `bson:"domain,omitempty"`
+	Key       string             `bson:"key"`
+	Action    string             `bson:"action"`
+	Actor     actorDoc           `bson:"actor"`
+	CreatedAt primitive.DateTime `bson:"createdAt"`
+	Changes   []fieldChangeDoc   `bson:"changes"`
+}
+
+type actorDoc struct {
+	Owner     string `bson:"owner,omitempty"`
+	Method    string `bson:"method,omitempty"`
+	Transport string `bson:"transport,omitempty"`
+}
+
+type fieldChangeDoc struct {
+	Field string `bson:"field"`
+	Old   string `bson:"old,omitempty"`
+	New   string `bson:"new,omitempty"`
+}
+
+// revisionCursor is the position of the last returned revision, encoded into an opaque string.
+type revisionCursor struct {
+	CreatedAt int64  `json:"c"`
+	ID        string `json:"i"`
+}
+
+var _ domain.LinkRevisionRepo = (*linkRevisionRepo)(nil)
+
+type linkRevisionRepo struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+}
+
+func NewLinkRevisionRepository(logger *slog.Logger, mongo *mongo.Client) *linkRevisionRepo {
+	return &linkRevisionRepo{
+		logger:     logger,
+		collection: mongo.Database(shortenerDBName).Collection(linkRevisionsCollectionName),
+	}
+}
+
+func (r *linkRevisionRepo) InsertMany(ctx context.Context, revisions []domain.LinkRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(revisions))
+	for _, revision := range revisions {
+		docs = append(docs, fromLinkRevisionToDocument(revision))
+	}
+
+	// unordered, so a single bad document does not prevent storing the others
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("insert link revisions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *linkRevisionRepo) FindMany(ctx context.Context, query domain.HistoryQuery) (domain.HistoryResult, error) {
+	filter := linkFilter(domain.LinkRef{Domain: query.LinkDomain, Key: query.LinkKey})
+
+	if query.Cursor != "" {
+		createdAt, id, err := decodeRevisionCursor(query.Cursor)
+		if err != nil {
+			return domain.HistoryResult{}, err
+		}
+
+		filter["$or"] = bson.A{
+			bson.M{"createdAt": bson.M{"$lt": createdAt}},
+			bson.M{"createdAt": createdAt, "_id": bson.M{"$lt": id}},
+		}
+	}
+
+	// one extra document is fetched to know if there is a next page
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(query.Limit) + 1)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.HistoryResult{}, fmt.Errorf("find link revisions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := make([]linkRevisionDoc, 0, query.Limit+1)
+	if err := cursor.All(ctx, &docs); err != nil {
+		return domain.HistoryResult{}, fmt.Errorf("decode link revisions: %w", err)
+	}
+
+	result := domain.HistoryResult{}
+	if len(docs) > query.Limit {
+		docs = docs[:query.Limit]
+		result.NextCursor = encodeRevisionCursor(docs[len(docs)-1])
+	}
+
+	result.Revisions = make([]domain.LinkRevision, 0, len(docs))
+	for _, doc := range docs {
+		result.Revisions = append(result.Revisions, fromDocumentToLinkRevision(doc))
+	}
+
+	return result, nil
+}
+
+func encodeRevisionCursor(lastDoc linkRevisionDoc) string {
+	data, _ := json.Marshal(revisionCursor{
+		CreatedAt: int64(lastDoc.CreatedAt),
+		ID:        lastDoc.ID.Hex(),
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeRevisionCursor(cursor string) (primitive.DateTime, primitive.ObjectID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+
+	var c revisionCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return 0, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return 0, primitive.NilObjectID, domain.ErrInvalidCursor
+	}
+
+	return primitive.DateTime(c.CreatedAt), id, nil
+}
+
+func fromLinkRevisionToDocument(revision domain.LinkRevision) linkRevisionDoc {
+	// the links always have an ObjectID, a malformed one is left out rather than losing the revision
+	linkID, _ := primitive.ObjectIDFromHex(revision.LinkID)
+
+	changes := make([]fieldChangeDoc, 0, len(revision.Changes))
+	for _, change := range revision.Changes {
+		changes = append(changes, fieldChangeDoc{Field: change.Field, Old: change.Old, New: change.New})
+	}
+
+	return linkRevisionDoc{
+		LinkID: linkID,
+		Domain: revision.Domain,
+		Key:    revision.Key,
+		Action: string(revision.Action),
+		Actor: actorDoc{
+			Owner:     revision.Actor.Owner,
+			Method:    string(revision.Actor.Method),
+			Transport: string(revision.Actor.Transport),
+		},
+		CreatedAt: primitive.NewDateTimeFromTime(revision.CreatedAt),
+		Changes:   changes,
+	}
+}
+
+func fromDocumentToLinkRevision(doc linkRevisionDoc) domain.LinkRevision {
+	changes := make([]domain.FieldChange, 0, len(doc.Changes))
+	for _, change := range doc.Changes {
+		changes = append(changes, domain.FieldChange{Field: change.Field, Old: change.Old, New: change.New})
+	}
+
+	revision := domain.LinkRevision{
+		ID:     doc.ID.Hex(),
+		Domain: doc.Domain,
+		Key:    doc.Key,
+		Action: domain.RevisionAction(doc.Action),
+		Actor: domain.Actor{
+			Owner:     doc.Actor.Owner,
+			Method:    domain.AuthMethod(doc.Actor.Method),
+			Transport: domain.Transport(doc.Actor.Transport),
+		},
+		CreatedAt: doc.CreatedAt.Time(),
+		Changes:   changes,
+	}
+	if !doc.LinkID.IsZero() {
+		revision.LinkID = doc.LinkID.Hex()
+	}
+
+	return revision
+}
@@ -0,0 +1,49 @@
+package infra
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestRevisionCursorRoundTrip(t *testing.T) {
+	lastDoc := linkRevisionDoc{
+		ID:        primitive.NewObjectID(),
+		CreatedAt: primitive.NewDateTimeFromTime(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	createdAt, id, err := decodeRevisionCursor(encodeRevisionCursor(lastDoc))
+	if err != nil {
+		t.Fatalf("decodeRevisionCursor() error = %v; want nil", err)
+	}
+	if createdAt != lastDoc.CreatedAt || id != lastDoc.ID {
+		t.Errorf("decodeRevisionCursor() = %v, %v; want %v, %v", createdAt, id, lastDoc.CreatedAt, lastDoc.ID)
+	}
+}
+
+func TestRevisionCursorInvalid(t *testing.T) {
+	valid := encodeRevisionCursor(linkRevisionDoc{ID: primitive.NewObjectID()})
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "Not Base64", cursor: "not a cursor!"},
+		{name: "Not JSON", cursor: encode("not json")},
+		{name: "Tampered ID", cursor: encode(`{"c":0,"i":"zzz"}`)},
+		{name: "Truncated", cursor: valid[:len(valid)/2]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeRevisionCursor(tt.cursor); err != domain.ErrInvalidCursor {
+				t.Errorf("decodeRevisionCursor(%q) error = %v; want %v", tt.cursor, err, domain.ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+var _ domain.PasswordAttemptRepo = (*passwordAttemptRepo)(nil)
+
+// passwordAttemptRepo keeps a Redis counter per link and client which expires with the window.
+type passwordAttemptRepo struct {
+	logger *slog.Logger
+	redis  *redis.Client
+}
+
+func NewPasswordAttemptRepository(logger *slog.Logger, redis *redis.Client) *passwordAttemptRepo {
+	return &passwordAttemptRepo{
+		logger: logger,
+		redis:  redis,
+	}
+}
+
+func (r *passwordAttemptRepo) Failures(ctx context.Context, link domain.LinkRef, clientIP string) (int, error) {
+	failures, err := r.redis.Get(ctx, genPasswordAttemptsKey(link, clientIP)).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return failures, err
+}
+
+func (r *passwordAttemptRepo) AddFailure(ctx context.Context, link domain.LinkRef, clientIP string, window time.Duration) error {
+	key := genPasswordAttemptsKey(link, clientIP)
+
+	pipe := r.redis.Pipeline()
+	pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+func genPasswordAttemptsKey(link domain.LinkRef, clientIP string) string {
+	return genCacheKey(link.String()+":"+clientIP, "passwordAttempts")
+}
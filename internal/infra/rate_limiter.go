@@ -0,0 +1,76 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+var _ domain.RateLimiter = (*rateLimiter)(nil)
+
+// slidingWindowLimit keeps the timestamps of the allowed requests of a client in a sorted set.
+// The Redis clock is used, so the instances of the service agree on the window.
+// Returns {allowed, retry after in microseconds}.
+var slidingWindowLimit = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+if redis.call('ZCARD', KEYS[1]) < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000))
+	return {1, 0}
+end
+
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {0, tonumber(oldest[2]) + window - now}
+`)
+
+// rateLimiter is a Redis sliding window log, the rejected requests are not counted,
+// so a client is limited to the number of its allowed requests in memory.
+type rateLimiter struct {
+	logger *slog.Logger
+	redis  *redis.Client
+	// makes the members of the sorted set unique when the timestamps collide
+	instanceID string
+	sequence   atomic.Uint64
+}
+
+func NewRateLimiter(logger *slog.Logger, redis *redis.Client) *rateLimiter {
+	return &rateLimiter{
+		logger:     logger,
+		redis:      redis,
+		instanceID: strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+}
+
+func (l *rateLimiter) Allow(ctx context.Context, route string, client string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	member := l.instanceID + ":" + strconv.FormatUint(l.sequence.Add(1), 36)
+
+	result, err := slidingWindowLimit.Run(ctx, l.redis,
+		[]string{genCacheKey(route+":"+client, "rateLimit")},
+		limit.Window.Microseconds(), limit.Requests, member,
+	).Int64Slice()
+	if err != nil {
+		return domain.RateLimitResult{}, fmt.Errorf("run sliding window script: %w", err)
+	}
+
+	if result[0] == 1 {
+		return domain.RateLimitResult{Allowed: true}, nil
+	}
+
+	return domain.RateLimitResult{
+		Allowed:    false,
+		RetryAfter: time.Duration(result[1]) * time.Microsecond,
+	}, nil
+}
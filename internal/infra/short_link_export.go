@@ -0,0 +1,62 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// exportBatchSize is the number of documents fetched per round trip of the export cursor.
+const exportBatchSize = 500
+
+func (r *shortLinkRepo) Export(ctx context.Context, filter domain.ExportFilter, fn func(domain.ShortLink) error) error {
+	// the links of the trash are not exported
+	filters := bson.A{bson.M{"deletedAt": nil}}
+	if filter.Domain != nil {
+		if *filter.Domain == "" {
+			// the links on the primary domain are stored without the field
+			filters = append(filters, bson.M{"domain": nil})
+		} else {
+			filters = append(filters, bson.M{"domain": *filter.Domain})
+		}
+	}
+	if !filter.CreatedAfter.IsZero() {
+		filters = append(filters, bson.M{"createdAt": bson.M{"$gte": primitive.NewDateTimeFromTime(filter.CreatedAfter)}})
+	}
+	if !filter.CreatedBefore.IsZero() {
+		filters = append(filters, bson.M{"createdAt": bson.M{"$lt": primitive.NewDateTimeFromTime(filter.CreatedBefore)}})
+	}
+
+	mongoFilter := bson.M{"$and": filters}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetBatchSize(exportBatchSize)
+
+	cursor, err := r.collection.Find(ctx, mongoFilter, opts)
+	if err != nil {
+		return fmt.Errorf("find short links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		// a new document each time, the omitted fields must not keep the values of the previous one
+		var doc shortLinkDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("decode short link: %w", err)
+		}
+		if err := fn(fromDocumentToEntity(doc)); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("iterate short links: %w", err)
+	}
+
+	return nil
+}
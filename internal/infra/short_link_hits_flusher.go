@@ -0,0 +1,231 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	// hashes of LinkRef.String() of the link to the number of hits not yet written to MongoDB
+	pendingHitsKey    = "shortener:pendingHits"
+	pendingBotHitsKey = "shortener:pendingBotHits"
+	// prevents several instances from applying the same deltas twice
+	pendingHitsLockKey  = "shortener:pendingHits:lock"
+	pendingHitsLockTTL  = 30 * time.Second
+	hitsFlushBatchSize  = 500
+	hitsFlushTimeout    = 10 * time.Second
+	hitsFlushMaxRetries = 3
+)
+
+// pendingCounters maps each hash of buffered hits to the document field it is flushed into.
+var pendingCounters = []struct {
+	hashKey string
+	field   string
+}{
+	{pendingHitsKey, "hits"},
+	{pendingBotHitsKey, "botHits"},
+}
+
+// subtractPendingHits decrements the flushed deltas and removes the fields which reach zero,
+// so hits counted during the flush are kept for the next one.
+var subtractPendingHits = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	local left = redis.call('HINCRBY', KEYS[1], ARGV[i], -tonumber(ARGV[i + 1]))
+	if left <= 0 then
+		redis.call('HDEL', KEYS[1], ARGV[i])
+	end
+end
+return 0
+`)
+
+// releasePendingHitsLock deletes the lock only when it is still owned by the caller.
+var releasePendingHitsLock = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// ShortLinkHitsFlusher periodically moves the hits buffered in Redis to MongoDB.
+type ShortLinkHitsFlusher struct {
+	logger     *slog.Logger
+	collection *mongo.Collection
+	redis      *redis.Client
+	lockID     string
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+func NewShortLinkHitsFlusher(logger *slog.Logger, mongo *mongo.Client, redis *redis.Client, interval time.Duration) *ShortLinkHitsFlusher {
+	f := &ShortLinkHitsFlusher{
+		logger:     logger,
+		collection: mongo.Database(shortenerDBName).Collection(shortLinksCollectionName),
+		redis:      redis,
+		lockID:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go f.run(interval)
+
+	return f
+}
+
+// Close stops the periodic flush and writes the remaining hits to MongoDB.
+func (f *ShortLinkHitsFlusher) Close(ctx context.Context) error {
+	select {
+	case <-f.stop:
+	default:
+		close(f.stop)
+	}
+
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// another instance may hold the lock for a moment, so the final flush is retried
+	for range hitsFlushMaxRetries {
+		flushed, err := f.flush(ctx)
+		if err != nil {
+			return err
+		}
+		if flushed {
+			return nil
+		}
+
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("pending hits lock is held by another instance, the hits stay buffered in Redis")
+}
+
+func (f *ShortLinkHitsFlusher) run(interval time.Duration) {
+	defer close(f.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stop:
+			return
+
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), hitsFlushTimeout)
+			if _, err := f.flush(ctx); err != nil {
+				f.logger.Error("unable to flush pending hits", slog.Any("error", err))
+			}
+			cancel()
+		}
+	}
+}
+
+// flush applies the pending hits to MongoDB. It returns false when another instance is flushing.
+func (f *ShortLinkHitsFlusher) flush(ctx context.Context) (bool, error) {
+	locked, err := f.redis.SetNX(ctx, pendingHitsLockKey, f.lockID, pendingHitsLockTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("acquire pending hits lock: %w", err)
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		if err := releasePendingHitsLock.Run(context.WithoutCancel(ctx), f.redis, []string{pendingHitsLockKey}, f.lockID).Err(); err != nil {
+			f.logger.Warn("unable to release pending hits lock", slog.Any("error", err))
+		}
+	}()
+
+	for _, counter := range pendingCounters {
+		if err := f.flushCounter(ctx, counter.hashKey, counter.field); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+func (f *ShortLinkHitsFlusher) flushCounter(ctx context.Context, hashKey string, field string) error {
+	pending, err := f.redis.HGetAll(ctx, hashKey).Result()
+	if err != nil {
+		return fmt.Errorf("read pending %s: %w", field, err)
+	}
+
+	keys := make([]string, 0, hitsFlushBatchSize)
+	deltas := make([]int64, 0, hitsFlushBatchSize)
+	for key, value := range pending {
+		delta, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || delta <= 0 {
+			continue
+		}
+
+		keys = append(keys, key)
+		deltas = append(deltas, delta)
+		if len(keys) == hitsFlushBatchSize {
+			if err := f.flushBatch(ctx, hashKey, field, keys, deltas); err != nil {
+				return err
+			}
+			keys, deltas = keys[:0], deltas[:0]
+		}
+	}
+
+	return f.flushBatch(ctx, hashKey, field, keys, deltas)
+}
+
+func (f *ShortLinkHitsFlusher) flushBatch(ctx context.Context, hashKey string, field string, keys []string, deltas []int64) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(keys))
+	for i, key := range keys {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(linkFilter(domain.ParseLinkRef(key))).
+			SetUpdate(bson.M{"$inc": bson.M{field: deltas[i]}}),
+		)
+	}
+
+	// the writes are ordered, so on failure everything before the failed write is applied
+	applied := len(models)
+	_, writeErr := f.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true))
+	if writeErr != nil {
+		applied = 0
+		if bulkErr, ok := writeErr.(mongo.BulkWriteException); ok && len(bulkErr.WriteErrors) > 0 {
+			applied = bulkErr.WriteErrors[0].Index
+		}
+	}
+
+	if applied > 0 {
+		args := make([]any, 0, applied*2)
+		for i := range applied {
+			args = append(args, keys[i], deltas[i])
+		}
+
+		// the deltas are written to MongoDB, a failure here means they are counted twice
+		if err := subtractPendingHits.Run(ctx, f.redis, []string{hashKey}, args...).Err(); err != nil {
+			return fmt.Errorf("subtract flushed %s: %w", field, err)
+		}
+	}
+
+	if writeErr != nil {
+		return fmt.Errorf("write pending %s: %w", field, writeErr)
+	}
+
+	return nil
+}
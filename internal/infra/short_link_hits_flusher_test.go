@@ -0,0 +1,96 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// newTestRedis returns a client of an in-memory Redis, which runs the Lua scripts too.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return server, client
+}
+
+func TestFlushBatch(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	keys := []string{"aaa", "bbb", "ccc"}
+	deltas := []int64{2, 3, 4}
+
+	tests := []struct {
+		name        string
+		response    bson.D
+		expectedErr bool
+		// pending hits left in Redis, the hits counted during the flush are kept
+		expectedPending map[string]string
+	}{
+		{
+			name:            "All Written",
+			response:        mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 3}, bson.E{Key: "nModified", Value: 3}),
+			expectedPending: map[string]string{"ccc": "1"},
+		},
+		{
+			name:            "Write Error In The Middle",
+			response:        mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 1, Code: 2, Message: "bad value"}),
+			expectedErr:     true,
+			expectedPending: map[string]string{"bbb": "3", "ccc": "5"},
+		},
+		{
+			name:            "Write Error On The First",
+			response:        mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 2, Message: "bad value"}),
+			expectedErr:     true,
+			expectedPending: map[string]string{"aaa": "2", "bbb": "3", "ccc": "5"},
+		},
+		{
+			name:            "Command Error",
+			response:        mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Message: "bad value"}),
+			expectedErr:     true,
+			expectedPending: map[string]string{"aaa": "2", "bbb": "3", "ccc": "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			redisServer, redisClient := newTestRedis(mt.T)
+			// "ccc" got one more hit after the flush read the pending hits
+			redisServer.HSet(pendingHitsKey, "aaa", "2", "bbb", "3", "ccc", "5")
+
+			f := &ShortLinkHitsFlusher{
+				logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+				collection: mt.Coll,
+				redis:      redisClient,
+			}
+			mt.AddMockResponses(tt.response)
+
+			err := f.flushBatch(context.Background(), pendingHitsKey, "hits", keys, deltas)
+			if (err != nil) != tt.expectedErr {
+				mt.Fatalf("flushBatch() error = %v; want error %v", err, tt.expectedErr)
+			}
+
+			pending, err := redisClient.HGetAll(context.Background(), pendingHitsKey).Result()
+			if err != nil {
+				mt.Fatalf("HGetAll() error = %v", err)
+			}
+			if len(pending) != len(tt.expectedPending) {
+				mt.Fatalf("pending hits = %v; want %v", pending, tt.expectedPending)
+			}
+			for key, value := range tt.expectedPending {
+				if pending[key] != value {
+					mt.Errorf("pending hits of %q = %q; want %q", key, pending[key], value)
+				}
+			}
+		})
+	}
+}
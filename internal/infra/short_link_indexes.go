@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -11,54 +12,167 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// indexSpec describes an index that must exist on a collection.
+// isPresent detects an equivalent index created earlier, possibly under another name.
+type indexSpec struct {
+	model     mongo.IndexModel
+	name      string
+	isPresent func(indexDoc bson.M) bool
+}
+
 func EnsureShortLinkIndexes(ctx context.Context, logger *slog.Logger, mongoClient *mongo.Client) error {
+	collection := mongoClient.Database(shortenerDBName).Collection(shortLinksCollectionName)
+
+	err := ensureIndexes(ctx, logger, collection, []indexSpec{
+		{
+			// the keys are unique per domain, the key comes first so the key prefix filter of the listing is served too
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "key", Value: 1}, {Key: "domain", Value: 1}},
+				Options: options.Index().SetName(shortLinksKeyDomainIndexName).SetUnique(true),
+			},
+			name:      shortLinksKeyDomainIndexName,
+			isPresent: hasIndexName(shortLinksKeyDomainIndexName),
+		},
+		{
+			// documents without the "expiresAt" field are never removed by the TTL monitor
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+				Options: options.Index().SetName(shortLinksExpiresAtIndexName).SetExpireAfterSeconds(0),
+			},
+			name:      shortLinksExpiresAtIndexName,
+			isPresent: isShortLinkExpiresAtTTLIndex,
+		},
+		// the listing is sorted by a field and "_id" as a tie-breaker for the cursor
+		{
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}},
+				Options: options.Index().SetName(shortLinksCreatedAtIndexName),
+			},
+			name:      shortLinksCreatedAtIndexName,
+			isPresent: hasIndexName(shortLinksCreatedAtIndexName),
+		},
+		{
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "hits", Value: -1}, {Key: "_id", Value: -1}},
+				Options: options.Index().SetName(shortLinksHitsIndexName),
+			},
+			name:      shortLinksHitsIndexName,
+			isPresent: hasIndexName(shortLinksHitsIndexName),
+		},
+		{
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "host", Value: 1}, {Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}},
+				Options: options.Index().SetName(shortLinksHostIndexName),
+			},
+			name:      shortLinksHostIndexName,
+			isPresent: hasIndexName(shortLinksHostIndexName),
+		},
+		{
+			// only the links of the trash have the field, the purge finds them by their deletion time
+			model: mongo.IndexModel{
+				Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+				Options: options.Index().SetName(shortLinksDeletedAtIndexName).SetSparse(true),
+			},
+			name:      shortLinksDeletedAtIndexName,
+			isPresent: hasIndexName(shortLinksDeletedAtIndexName),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the unique index of the key alone, created before the domains, would reject a key used on another domain.
+	// It is dropped only once the index per domain exists, so the keys are never left without a unique index
+	return dropIndexes(ctx, logger, collection, isShortLinkKeyUniqueIndex)
+}
+
+func ensureIndexes(ctx context.Context, logger *slog.Logger, collection *mongo.Collection, specs []indexSpec) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	collection := mongoClient.Database(shortenerDBName).Collection(shortLinksCollectionName)
 	indexesCursor, err := collection.Indexes().List(timeoutCtx)
 	if err != nil {
 		return fmt.Errorf("list indexes: %w", err)
 	}
 	defer indexesCursor.Close(timeoutCtx)
 
+	var indexDocs []bson.M
 	for indexesCursor.Next(timeoutCtx) {
 		var indexDoc bson.M
 		if err := indexesCursor.Decode(&indexDoc); err != nil {
 			return fmt.Errorf("decode index document: %w", err)
 		}
 
-		if isShortLinkKeyUniqueIndex(indexDoc) {
-			logger.Debug("mongodb index already present; skipping creation",
-				slog.String("collection", shortLinksCollectionName),
-				slog.String("index", shortLinksUniqueKeyIndexName),
-			)
-
-			return nil
-		}
+		indexDocs = append(indexDocs, indexDoc)
 	}
 
 	if err := indexesCursor.Err(); err != nil {
 		return fmt.Errorf("iterate indexes: %w", err)
 	}
 
-	indexModel := mongo.IndexModel{
-		Keys:    bson.D{{Key: "key", Value: 1}},
-		Options: options.Index().SetName(shortLinksUniqueKeyIndexName).SetUnique(true),
+	for _, spec := range specs {
+		if slices.ContainsFunc(indexDocs, spec.isPresent) {
+			logger.Debug("mongodb index already present; skipping creation",
+				slog.String("collection", collection.Name()),
+				slog.String("index", spec.name),
+			)
+
+			continue
+		}
+
+		if _, err := collection.Indexes().CreateOne(timeoutCtx, spec.model); err != nil {
+			return fmt.Errorf("create index %q: %w", spec.name, err)
+		}
+
+		logger.Info("mongodb index created",
+			slog.String("collection", collection.Name()),
+			slog.String("index", spec.name),
+		)
 	}
 
-	if _, err := collection.Indexes().CreateOne(timeoutCtx, indexModel); err != nil {
-		return fmt.Errorf("create index %q: %w", shortLinksUniqueKeyIndexName, err)
+	return nil
+}
+
+// dropIndexes removes the indexes of the collection which are no longer wanted.
+func dropIndexes(ctx context.Context, logger *slog.Logger, collection *mongo.Collection, isObsolete func(indexDoc bson.M) bool) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var indexDocs []bson.M
+	indexesCursor, err := collection.Indexes().List(timeoutCtx)
+	if err != nil {
+		return fmt.Errorf("list indexes: %w", err)
 	}
+	if err := indexesCursor.All(timeoutCtx, &indexDocs); err != nil {
+		return fmt.Errorf("decode index documents: %w", err)
+	}
+
+	for _, indexDoc := range indexDocs {
+		if !isObsolete(indexDoc) {
+			continue
+		}
+
+		name, _ := indexDoc["name"].(string)
+		if _, err := collection.Indexes().DropOne(timeoutCtx, name); err != nil {
+			return fmt.Errorf("drop index %q: %w", name, err)
+		}
 
-	logger.Info("mongodb index created",
-		slog.String("collection", shortLinksCollectionName),
-		slog.String("index", shortLinksUniqueKeyIndexName),
-	)
+		logger.Info("mongodb index dropped",
+			slog.String("collection", collection.Name()),
+			slog.String("index", name),
+		)
+	}
 
 	return nil
 }
 
+func hasIndexName(name string) func(indexDoc bson.M) bool {
+	return func(indexDoc bson.M) bool {
+		indexName, _ := indexDoc["name"].(string)
+		return indexName == name
+	}
+}
+
 func isShortLinkKeyUniqueIndex(indexDoc bson.M) bool {
 	indexName, hasName := indexDoc["name"].(string)
 	if hasName && indexName == shortLinksUniqueKeyIndexName {
@@ -70,6 +184,10 @@ func isShortLinkKeyUniqueIndex(indexDoc bson.M) bool {
 		return false
 	}
 
+	if countIndexKeys(indexDoc["key"]) != 1 {
+		return false
+	}
+
 	keyOrder, ok := getIndexKeyOrder(indexDoc["key"], "key")
 	if !ok {
 		return false
@@ -78,6 +196,21 @@ func isShortLinkKeyUniqueIndex(indexDoc bson.M) bool {
 	return keyOrder == 1
 }
 
+func isShortLinkExpiresAtTTLIndex(indexDoc bson.M) bool {
+	indexName, hasName := indexDoc["name"].(string)
+	if hasName && indexName == shortLinksExpiresAtIndexName {
+		return true
+	}
+
+	if _, isTTL := indexDoc["expireAfterSeconds"]; !isTTL {
+		return false
+	}
+
+	_, ok := getIndexKeyOrder(indexDoc["key"], "expiresAt")
+
+	return ok
+}
+
 func getIndexKeyOrder(indexKeys any, key string) (int64, bool) {
 	switch typed := indexKeys.(type) {
 	case bson.M:
@@ -93,6 +226,17 @@ func getIndexKeyOrder(indexKeys any, key string) (int64, bool) {
 	return 0, false
 }
 
+func countIndexKeys(indexKeys any) int {
+	switch typed := indexKeys.(type) {
+	case bson.M:
+		return len(typed)
+	case bson.D:
+		return len(typed)
+	}
+
+	return 0
+}
+
 func castIndexOrder(v any) (int64, bool) {
 	switch value := v.(type) {
 	case int:
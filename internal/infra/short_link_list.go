@@ -0,0 +1,150 @@
+package infra
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// listCursor is the position of the last returned document, encoded into an opaque string.
+// The sort options are part of the cursor, so it cannot be reused with another sort order.
+type listCursor struct {
+	SortBy    domain.ListSortField `json:"s"`
+	Ascending bool                 `json:"a"`
+	Hits      uint                 `json:"h,omitempty"`
+	CreatedAt int64                `json:"c,omitempty"`
+	ID        string               `json:"i"`
+}
+
+func (r *shortLinkRepo) FindMany(ctx context.Context, query domain.ListQuery) (domain.ListResult, error) {
+	sortField := "createdAt"
+	if query.SortBy == domain.ListSortByHits {
+		sortField = "hits"
+	}
+
+	sortOrder := -1
+	if query.Ascending {
+		sortOrder = 1
+	}
+
+	// the active links and the links of the trash are listed separately
+	deletedFilter := bson.M{"deletedAt": nil}
+	if query.Deleted {
+		deletedFilter = bson.M{"deletedAt": bson.M{"$ne": nil}}
+	}
+
+	filters := bson.A{deletedFilter}
+	if query.Host != "" {
+		filters = append(filters, bson.M{"host": query.Host})
+	}
+	if query.KeyPrefix != "" {
+		// an anchored regex without options is served by the unique key index
+		filters = append(filters, bson.M{"key": bson.M{"$regex": "^" + regexp.QuoteMeta(query.KeyPrefix)}})
+	}
+	if !query.CreatedAfter.IsZero() {
+		filters = append(filters, bson.M{"createdAt": bson.M{"$gte": primitive.NewDateTimeFromTime(query.CreatedAfter)}})
+	}
+	if !query.CreatedBefore.IsZero() {
+		filters = append(filters, bson.M{"createdAt": bson.M{"$lt": primitive.NewDateTimeFromTime(query.CreatedBefore)}})
+	}
+
+	if query.Cursor != "" {
+		cursorFilter, err := decodeListCursorFilter(query, sortField)
+		if err != nil {
+			return domain.ListResult{}, err
+		}
+		filters = append(filters, cursorFilter)
+	}
+
+	filter := bson.M{"$and": filters}
+
+	// one extra document is fetched to know if there is a next page
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortField, Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetLimit(int64(query.Limit) + 1)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.ListResult{}, fmt.Errorf("find short links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := make([]shortLinkDoc, 0, query.Limit+1)
+	if err := cursor.All(ctx, &docs); err != nil {
+		return domain.ListResult{}, fmt.Errorf("decode short links: %w", err)
+	}
+
+	result := domain.ListResult{}
+	if len(docs) > query.Limit {
+		docs = docs[:query.Limit]
+		result.NextCursor = encodeListCursor(query, docs[len(docs)-1])
+	}
+
+	result.Links = make([]domain.ShortLink, 0, len(docs))
+	for _, doc := range docs {
+		result.Links = append(result.Links, fromDocumentToEntity(doc))
+	}
+
+	return result, nil
+}
+
+func encodeListCursor(query domain.ListQuery, lastDoc shortLinkDoc) string {
+	c := listCursor{
+		SortBy:    query.SortBy,
+		Ascending: query.Ascending,
+		ID:        lastDoc.ID.Hex(),
+	}
+	if query.SortBy == domain.ListSortByHits {
+		c.Hits = lastDoc.Hits
+	} else {
+		c.CreatedAt = int64(lastDoc.CreatedAt)
+	}
+
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursorFilter builds the keyset condition which selects the documents after the cursor.
+func decodeListCursorFilter(query domain.ListQuery, sortField string) (bson.M, error) {
+	data, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	if c.SortBy != query.SortBy || c.Ascending != query.Ascending {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var value any = primitive.DateTime(c.CreatedAt)
+	if query.SortBy == domain.ListSortByHits {
+		value = c.Hits
+	}
+
+	op := "$lt"
+	if query.Ascending {
+		op = "$gt"
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{sortField: bson.M{op: value}},
+		bson.M{sortField: value, "_id": bson.M{op: id}},
+	}}, nil
+}
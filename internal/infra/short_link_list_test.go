@@ -0,0 +1,90 @@
+package infra
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestListCursorRoundTrip(t *testing.T) {
+	lastDoc := shortLinkDoc{
+		ID:        primitive.NewObjectID(),
+		Hits:      42,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		name           string
+		query          domain.ListQuery
+		sortField      string
+		expectedFilter bson.M
+	}{
+		{
+			name:      "Created At Descending",
+			query:     domain.ListQuery{SortBy: domain.ListSortByCreatedAt},
+			sortField: "createdAt",
+			expectedFilter: bson.M{"$or": bson.A{
+				bson.M{"createdAt": bson.M{"$lt": lastDoc.CreatedAt}},
+				bson.M{"createdAt": lastDoc.CreatedAt, "_id": bson.M{"$lt": lastDoc.ID}},
+			}},
+		},
+		{
+			name:      "Hits Ascending",
+			query:     domain.ListQuery{SortBy: domain.ListSortByHits, Ascending: true},
+			sortField: "hits",
+			expectedFilter: bson.M{"$or": bson.A{
+				bson.M{"hits": bson.M{"$gt": lastDoc.Hits}},
+				bson.M{"hits": lastDoc.Hits, "_id": bson.M{"$gt": lastDoc.ID}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			query.Cursor = encodeListCursor(tt.query, lastDoc)
+
+			filter, err := decodeListCursorFilter(query, tt.sortField)
+			if err != nil {
+				t.Fatalf("decodeListCursorFilter() error = %v; want nil", err)
+			}
+			if !reflect.DeepEqual(filter, tt.expectedFilter) {
+				t.Errorf("decodeListCursorFilter() = %v; want %v", filter, tt.expectedFilter)
+			}
+		})
+	}
+}
+
+func TestListCursorInvalid(t *testing.T) {
+	query := domain.ListQuery{SortBy: domain.ListSortByCreatedAt}
+	valid := encodeListCursor(query, shortLinkDoc{ID: primitive.NewObjectID()})
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+		query  domain.ListQuery
+	}{
+		{name: "Not Base64", cursor: "not a cursor!", query: query},
+		{name: "Not JSON", cursor: encode("not json"), query: query},
+		{name: "Tampered ID", cursor: encode(`{"s":"createdAt","a":false,"i":"zzz"}`), query: query},
+		{name: "Truncated", cursor: valid[:len(valid)/2], query: query},
+		{name: "Other Sort Field", cursor: valid, query: domain.ListQuery{SortBy: domain.ListSortByHits}},
+		{name: "Other Sort Order", cursor: valid, query: domain.ListQuery{SortBy: domain.ListSortByCreatedAt, Ascending: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Cursor = tt.cursor
+			if _, err := decodeListCursorFilter(tt.query, "createdAt"); err != domain.ErrInvalidCursor {
+				t.Errorf("decodeListCursorFilter(%q) error = %v; want %v", tt.cursor, err, domain.ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -3,8 +3,11 @@ package infra
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,18 +23,67 @@ const (
 	shortenerDBName              = "shortener"
 	shortLinksCollectionName     = "short_links"
 	shortLinksUniqueKeyIndexName = "short_links_key_unique"
+	shortLinksKeyDomainIndexName = "short_links_key_domain_unique"
+	shortLinksExpiresAtIndexName = "short_links_expires_at_ttl"
+	shortLinksCreatedAtIndexName = "short_links_created_at"
+	shortLinksHitsIndexName      = "short_links_hits"
+	shortLinksHostIndexName      = "short_links_host_created_at"
+	shortLinksDeletedAtIndexName = "short_links_deleted_at"
+	// maximum lifetime of a cache entry, shortened for links expiring sooner
+	cacheTTL = time.Hour * 24
 )
 
 type shortLinkDoc struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Key         string             `bson:"key"`
-	OriginalURL string             `bson:"originalURL"`
-	Hits        uint               `bson:"hits"`
-	CreatedAt   primitive.DateTime `bson:"createdAt"`
+	ID  primitive.ObjectID `bson:"_id,omitempty"`
+	Key string             `bson:"key"`
+	// the field is omitted for links on the primary domain, so the links created before the domains stay on it
+	Domain      string `bson:"domain,omitempty"`
+	OriginalURL string `bson:"originalURL"`
+	// lowercase host of the original URL, denormalized for filtering
+	Host      string             `bson:"host"`
+	Hits      uint               `bson:"hits"`
+	CreatedAt primitive.DateTime `bson:"createdAt"`
+	// the field is omitted for links without expiry, so the TTL index ignores them
+	ExpiresAt *primitive.DateTime `bson:"expiresAt,omitempty"`
+	// the field is omitted for links without hit limit
+	MaxHits uint `bson:"maxHits,omitempty"`
+	// the field is omitted for links without password
+	PasswordHash string `bson:"passwordHash,omitempty"`
+	// the field is omitted for links using the server default
+	RedirectStatus int `bson:"redirectStatus,omitempty"`
+	// the fields are omitted for links without forwarding
+	QueryForwarding string `bson:"queryForwarding,omitempty"`
+	ForwardPath     bool   `bson:"forwardPath,omitempty"`
+	// the fields are set only for the links in the trash
+	DeletedAt *primitive.DateTime `bson:"deletedAt,omitempty"`
+	DeletedBy string              `bson:"deletedBy,omitempty"`
+	// the field is omitted for the enabled links, so the links created before the flag stay enabled
+	Disabled bool `bson:"disabled,omitempty"`
+}
+
+type redirectTargetDoc struct {
+	ID              primitive.ObjectID  `bson:"_id"`
+	OriginalURL     string              `bson:"originalURL"`
+	Hits            uint                `bson:"hits"`
+	ExpiresAt       *primitive.DateTime `bson:"expiresAt,omitempty"`
+	MaxHits         uint                `bson:"maxHits,omitempty"`
+	PasswordHash    string              `bson:"passwordHash,omitempty"`
+	RedirectStatus  int                 `bson:"redirectStatus,omitempty"`
+	QueryForwarding string              `bson:"queryForwarding,omitempty"`
+	ForwardPath     bool                `bson:"forwardPath,omitempty"`
+	Disabled        bool                `bson:"disabled,omitempty"`
+}
+
+type hitsDoc struct {
+	Hits    uint `bson:"hits"`
+	MaxHits uint `bson:"maxHits,omitempty"`
 }
 
 type statsDoc struct {
+	ID        primitive.ObjectID `bson:"_id"`
 	Hits      uint               `bson:"hits"`
+	BotHits   uint               `bson:"botHits"`
+	MaxHits   uint               `bson:"maxHits,omitempty"`
 	CreatedAt primitive.DateTime `bson:"createdAt"`
 }
 
@@ -41,13 +93,16 @@ type shortLinkRepo struct {
 	logger     *slog.Logger
 	collection *mongo.Collection
 	redis      *redis.Client
+	// count hits of unlimited links in Redis, the ShortLinkHitsFlusher moves them to MongoDB
+	bufferHits bool
 }
 
-func NewShortLinkRepository(logger *slog.Logger, mongo *mongo.Client, redis *redis.Client) *shortLinkRepo {
+func NewShortLinkRepository(logger *slog.Logger, mongo *mongo.Client, redis *redis.Client, bufferHits bool) *shortLinkRepo {
 	return &shortLinkRepo{
 		logger:     logger,
 		collection: mongo.Database(shortenerDBName).Collection(shortLinksCollectionName),
 		redis:      redis,
+		bufferHits: bufferHits,
 	}
 }
 
@@ -70,15 +125,15 @@ func (r *shortLinkRepo) InsertOne(ctx context.Context, shortLink domain.ShortLin
 	// trying to cache the entity
 	if err := r.setEntityCache(ctx, shortLink); err != nil {
 		r.logger.Warn("unable to cache short URL entity",
-			slog.String("key", shortLink.Key),
+			slog.String("key", shortLink.Ref().String()),
 			slog.String("originalURL", shortLink.OriginalURL),
 			slog.Any("error", err),
 		)
 	}
-	// trying to cache the original URL
-	if err := r.setOriginalURLCache(ctx, shortLink.Key, shortLink.OriginalURL); err != nil {
-		r.logger.Warn("unable to cache original URL for short link",
-			slog.String("key", shortLink.Key),
+	// trying to cache the redirect target
+	if err := r.setRedirectTargetCache(ctx, shortLink.Ref(), newRedirectTarget(shortLink), shortLink.ExpiresAt); err != nil {
+		r.logger.Warn("unable to cache redirect target for short link",
+			slog.String("key", shortLink.Ref().String()),
 			slog.String("originalURL", shortLink.OriginalURL),
 			slog.Any("error", err),
 		)
@@ -87,11 +142,64 @@ func (r *shortLinkRepo) InsertOne(ctx context.Context, shortLink domain.ShortLin
 	return insertID, nil
 }
 
-func (r *shortLinkRepo) FindOne(ctx context.Context, key string) (domain.ShortLink, error) {
-	cachedEntity, err := r.getEntityCache(ctx, key)
+func (r *shortLinkRepo) InsertMany(ctx context.Context, shortLinks []domain.ShortLink) ([]error, error) {
+	docs := make([]any, 0, len(shortLinks))
+	for i := range shortLinks {
+		doc := fromEntityToDocument(shortLinks[i])
+		// the IDs are set here, so the inserted links can be cached with them
+		doc.ID = primitive.NewObjectID()
+		shortLinks[i].ID = doc.ID.Hex()
+		docs = append(docs, doc)
+	}
+
+	insertErrs := make([]error, len(shortLinks))
+	// unordered, so a taken key does not stop the insertion of the following links
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+			return nil, err
+		}
+
+		for _, writeErr := range bulkErr.WriteErrors {
+			if writeErr.Index < 0 || writeErr.Index >= len(insertErrs) {
+				continue
+			}
+			if mongo.IsDuplicateKeyError(writeErr.WriteError) {
+				insertErrs[writeErr.Index] = domain.ErrShortLinkKeyExists
+			} else {
+				insertErrs[writeErr.Index] = writeErr.WriteError
+			}
+		}
+	}
+
+	for i, shortLink := range shortLinks {
+		if insertErrs[i] != nil {
+			continue
+		}
+
+		if err := r.setEntityCache(ctx, shortLink); err != nil {
+			r.logger.Warn("unable to cache short URL entity",
+				slog.String("key", shortLink.Ref().String()),
+				slog.Any("error", err),
+			)
+		}
+		if err := r.setRedirectTargetCache(ctx, shortLink.Ref(), newRedirectTarget(shortLink), shortLink.ExpiresAt); err != nil {
+			r.logger.Warn("unable to cache redirect target for short link",
+				slog.String("key", shortLink.Ref().String()),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return insertErrs, nil
+}
+
+func (r *shortLinkRepo) FindOne(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	cachedEntity, err := r.getEntityCache(ctx, link)
 	if err != nil {
 		r.logger.Warn("unable to fetch cache for short URL entity",
-			slog.String("key", key),
+			slog.String("key", link.String()),
 			slog.Any("error", err),
 		)
 	} else if cachedEntity != nil {
@@ -99,7 +207,7 @@ func (r *shortLinkRepo) FindOne(ctx context.Context, key string) (domain.ShortLi
 	}
 
 	doc := new(shortLinkDoc)
-	err = r.collection.FindOne(ctx, bson.M{"key": key}).Decode(doc)
+	err = r.collection.FindOne(ctx, activeLinkFilter(link)).Decode(doc)
 	if err == mongo.ErrNoDocuments {
 		return domain.ShortLink{}, domain.ErrShortLinkNotFound
 	}
@@ -112,7 +220,7 @@ func (r *shortLinkRepo) FindOne(ctx context.Context, key string) (domain.ShortLi
 	// trying to cache the entity
 	if err := r.setEntityCache(ctx, ent); err != nil {
 		r.logger.Warn("unable to set cache for short URL entity",
-			slog.String("key", key),
+			slog.String("key", link.String()),
 			slog.Any("error", err),
 		)
 	}
@@ -120,84 +228,267 @@ func (r *shortLinkRepo) FindOne(ctx context.Context, key string) (domain.ShortLi
 	return ent, nil
 }
 
-func (r *shortLinkRepo) FindOriginalURL(ctx context.Context, key string) (string, error) {
-	// trying to fetch original URL form cache
-	cachedOriginalURL, err := r.getOriginalURLCache(ctx, key)
+func (r *shortLinkRepo) FindRedirectTarget(ctx context.Context, link domain.LinkRef) (domain.RedirectTarget, error) {
+	// trying to fetch redirect target form cache
+	cachedTarget, err := r.getRedirectTargetCache(ctx, link)
 	if err != nil {
-		r.logger.Warn("unable to fetch cache for original URL",
-			slog.String("key", key),
+		r.logger.Warn("unable to fetch cache for redirect target",
+			slog.String("key", link.String()),
 			slog.Any("err", err),
 		)
-	} else if cachedOriginalURL != "" {
-		return cachedOriginalURL, nil
+	} else if cachedTarget != nil {
+		return *cachedTarget, nil
 	}
 
-	// find original URL form DB
-	var result bson.M
-	filter := bson.M{"key": key}
-	projection := bson.M{"originalURL": 1, "_id": 0}
-	err = r.collection.FindOne(ctx, filter, options.FindOne().SetProjection(projection)).Decode(&result)
+	// find redirect target form DB
+	result := new(redirectTargetDoc)
+	filter := activeLinkFilter(link)
+	projection := bson.M{"originalURL": 1, "hits": 1, "expiresAt": 1, "maxHits": 1, "passwordHash": 1, "redirectStatus": 1, "queryForwarding": 1, "forwardPath": 1, "disabled": 1}
+	err = r.collection.FindOne(ctx, filter, options.FindOne().SetProjection(projection)).Decode(result)
 	if err == mongo.ErrNoDocuments {
-		return "", domain.ErrShortLinkNotFound
+		return domain.RedirectTarget{}, domain.ErrShortLinkNotFound
 	}
 	if err != nil {
-		return "", err
+		return domain.RedirectTarget{}, err
 	}
 
-	originalURL, ok := result["originalURL"].(string)
-	if !ok {
-		return "", fmt.Errorf("unable to find the property %q", originalURL)
+	// the TTL monitor removes expired documents with a delay, so check the expiry here
+	expiresAt := fromOptionalDateTime(result.ExpiresAt)
+	if !expiresAt.IsZero() && !time.Now().Before(expiresAt) {
+		return domain.RedirectTarget{}, domain.ErrShortLinkExpired
+	}
+	// exhausted links are not cached, so the next redirects keep failing fast
+	if result.MaxHits > 0 && result.Hits >= result.MaxHits {
+		return domain.RedirectTarget{}, domain.ErrShortLinkExhausted
 	}
 
-	err = r.setOriginalURLCache(ctx, key, originalURL)
+	target := domain.RedirectTarget{
+		LinkID:          result.ID.Hex(),
+		OriginalURL:     result.OriginalURL,
+		ExpiresAt:       expiresAt,
+		MaxHits:         result.MaxHits,
+		PasswordHash:    result.PasswordHash,
+		RedirectStatus:  result.RedirectStatus,
+		QueryForwarding: domain.QueryForwarding(result.QueryForwarding),
+		ForwardPath:     result.ForwardPath,
+		Disabled:        result.Disabled,
+	}
+	err = r.setRedirectTargetCache(ctx, link, target, expiresAt)
 	if err != nil {
-		r.logger.Warn("unable to set cache for original URL",
-			slog.String("key", key),
+		r.logger.Warn("unable to set cache for redirect target",
+			slog.String("key", link.String()),
 			slog.Any("err", err),
 		)
 	}
 
-	return originalURL, nil
+	return target, nil
 }
 
-func (r *shortLinkRepo) DeleteOne(ctx context.Context, key string) error {
-	res, err := r.collection.DeleteOne(ctx, bson.M{"key": key})
+func (r *shortLinkRepo) UpdateOne(ctx context.Context, shortLink domain.ShortLink) (domain.ShortLink, error) {
+	set := bson.M{
+		"originalURL": shortLink.OriginalURL,
+		"host":        extractHost(shortLink.OriginalURL),
+	}
+	unset := bson.M{}
+
+	// optional fields are removed instead of being stored as zero values
+	if shortLink.ExpiresAt.IsZero() {
+		unset["expiresAt"] = ""
+	} else {
+		set["expiresAt"] = primitive.NewDateTimeFromTime(shortLink.ExpiresAt)
+	}
+	if shortLink.MaxHits == 0 {
+		unset["maxHits"] = ""
+	} else {
+		set["maxHits"] = shortLink.MaxHits
+	}
+	if shortLink.PasswordHash == "" {
+		unset["passwordHash"] = ""
+	} else {
+		set["passwordHash"] = shortLink.PasswordHash
+	}
+	if shortLink.RedirectStatus == 0 {
+		unset["redirectStatus"] = ""
+	} else {
+		set["redirectStatus"] = shortLink.RedirectStatus
+	}
+	if shortLink.QueryForwarding == domain.QueryForwardingNone {
+		unset["queryForwarding"] = ""
+	} else {
+		set["queryForwarding"] = string(shortLink.QueryForwarding)
+	}
+	if !shortLink.ForwardPath {
+		unset["forwardPath"] = ""
+	} else {
+		set["forwardPath"] = true
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	doc := new(shortLinkDoc)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, activeLinkFilter(shortLink.Ref()), update, opts).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return domain.ShortLink{}, domain.ErrShortLinkNotFound
+	}
 	if err != nil {
-		return err
+		return domain.ShortLink{}, err
 	}
-	if res.DeletedCount == 0 {
-		return domain.ErrShortLinkNotFound
+
+	err = r.deleteEntityCache(ctx, shortLink.Ref())
+	if err != nil {
+		return domain.ShortLink{}, fmt.Errorf("delete entity form cache: %w", err)
 	}
 
-	err = r.deleteEntityCache(ctx, key)
+	err = r.deleteRedirectTargetCache(ctx, shortLink.Ref())
 	if err != nil {
-		return fmt.Errorf("delete entity form cache: %w", err)
+		return domain.ShortLink{}, fmt.Errorf("delete redirect target form cache: %w", err)
 	}
 
-	err = r.deleteOriginalURLCache(ctx, key)
+	return fromDocumentToEntity(*doc), nil
+}
+
+func (r *shortLinkRepo) SetDisabled(ctx context.Context, link domain.LinkRef, disabled bool) (domain.ShortLink, error) {
+	update := bson.M{"$unset": bson.M{"disabled": ""}}
+	if disabled {
+		update = bson.M{"$set": bson.M{"disabled": true}}
+	}
+
+	doc := new(shortLinkDoc)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	err := r.collection.FindOneAndUpdate(ctx, activeLinkFilter(link), update, opts).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return domain.ShortLink{}, domain.ErrShortLinkNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("delete original URL form cache: %w", err)
+		return domain.ShortLink{}, err
 	}
 
-	return nil
+	// the flag is part of both cache entries, the next lookups read it again from MongoDB
+	err = r.deleteEntityCache(ctx, link)
+	if err != nil {
+		return domain.ShortLink{}, fmt.Errorf("delete entity form cache: %w", err)
+	}
+
+	err = r.deleteRedirectTargetCache(ctx, link)
+	if err != nil {
+		return domain.ShortLink{}, fmt.Errorf("delete redirect target form cache: %w", err)
+	}
+
+	return fromDocumentToEntity(*doc), nil
 }
 
-func (r *shortLinkRepo) IncreaseHits(ctx context.Context, key string) error {
-	filter := bson.M{"key": key}
+func (r *shortLinkRepo) DeleteOne(ctx context.Context, link domain.LinkRef, deletedBy string) (domain.ShortLink, error) {
+	set := bson.M{"deletedAt": primitive.NewDateTimeFromTime(time.Now())}
+	if deletedBy != "" {
+		set["deletedBy"] = deletedBy
+	}
+
+	doc := new(shortLinkDoc)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, activeLinkFilter(link), bson.M{"$set": set}, opts).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return domain.ShortLink{}, domain.ErrShortLinkNotFound
+	}
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	err = r.deleteEntityCache(ctx, link)
+	if err != nil {
+		return domain.ShortLink{}, fmt.Errorf("delete entity form cache: %w", err)
+	}
+
+	err = r.deleteRedirectTargetCache(ctx, link)
+	if err != nil {
+		return domain.ShortLink{}, fmt.Errorf("delete redirect target form cache: %w", err)
+	}
+
+	// the pending hits were counted before the deletion, so they are still flushed into the document
+	return fromDocumentToEntity(*doc), nil
+}
+
+func (r *shortLinkRepo) IncreaseHits(ctx context.Context, link domain.LinkRef, limited bool) error {
+	if r.bufferHits && !limited {
+		if err := r.redis.HIncrBy(ctx, pendingHitsKey, link.String(), 1).Err(); err != nil {
+			return fmt.Errorf("failed to buffer hit for link %q: %w", link.String(), err)
+		}
+
+		return nil
+	}
+
+	// a limit can be added to a link with buffered hits, they count against it until they are flushed
+	var pendingHits uint
+	if r.bufferHits {
+		pendingHits = r.findPendingHits(ctx, pendingHitsKey, link)
+	}
+
+	// the hit limit is checked and incremented in one atomic operation,
+	// so concurrent redirects cannot overshoot it
+	filter := activeLinkFilter(link)
+	filter["$or"] = bson.A{
+		bson.M{"maxHits": bson.M{"$exists": false}},
+		bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$add": bson.A{"$hits", pendingHits}}, "$maxHits"}}},
+	}
 	update := bson.M{"$inc": bson.M{"hits": 1}}
+	opts := options.FindOneAndUpdate().
+		SetProjection(bson.M{"hits": 1, "maxHits": 1, "_id": 0}).
+		SetReturnDocument(options.After)
 
-	_, err := r.collection.UpdateOne(ctx, filter, update)
+	doc := new(hitsDoc)
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		count, err := r.collection.CountDocuments(ctx, activeLinkFilter(link), options.Count().SetLimit(1))
+		if err != nil {
+			return fmt.Errorf("failed to check link %q: %w", link.String(), err)
+		}
+		if count == 0 {
+			return domain.ErrShortLinkNotFound
+		}
+
+		r.invalidateCache(ctx, link)
+
+		return domain.ErrShortLinkExhausted
+	}
 	if err != nil {
-		return fmt.Errorf("failed to increase hits for link with key %q: %w", key, err)
+		return fmt.Errorf("failed to increase hits for link %q: %w", link.String(), err)
+	}
+
+	// the last allowed hit is consumed, drop the cached data of the link
+	if doc.MaxHits > 0 && doc.Hits+pendingHits >= doc.MaxHits {
+		r.invalidateCache(ctx, link)
 	}
 
 	return nil
 }
 
-func (r *shortLinkRepo) FindStats(ctx context.Context, key string) (domain.StatsResult, error) {
+func (r *shortLinkRepo) IncreaseBotHits(ctx context.Context, link domain.LinkRef) error {
+	if r.bufferHits {
+		if err := r.redis.HIncrBy(ctx, pendingBotHitsKey, link.String(), 1).Err(); err != nil {
+			return fmt.Errorf("failed to buffer bot hit for link %q: %w", link.String(), err)
+		}
+
+		return nil
+	}
+
+	res, err := r.collection.UpdateOne(ctx, activeLinkFilter(link), bson.M{"$inc": bson.M{"botHits": 1}})
+	if err != nil {
+		return fmt.Errorf("failed to increase bot hits for link %q: %w", link.String(), err)
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrShortLinkNotFound
+	}
+
+	return nil
+}
+
+func (r *shortLinkRepo) FindStats(ctx context.Context, link domain.LinkRef) (domain.StatsResult, error) {
 	statsDoc := new(statsDoc)
-	filter := bson.M{"key": key}
-	projection := bson.M{"hits": 1, "createdAt": 1, "_id": 0}
+	filter := activeLinkFilter(link)
+	projection := bson.M{"hits": 1, "botHits": 1, "maxHits": 1, "createdAt": 1}
 	err := r.collection.FindOne(ctx, filter, options.FindOne().SetProjection(projection)).Decode(statsDoc)
 	if err == mongo.ErrNoDocuments {
 		return domain.StatsResult{}, domain.ErrShortLinkNotFound
@@ -206,51 +497,109 @@ func (r *shortLinkRepo) FindStats(ctx context.Context, key string) (domain.Stats
 		return domain.StatsResult{}, err
 	}
 
+	// add the hits which are not flushed to MongoDB yet
+	pendingHits := r.findPendingHits(ctx, pendingHitsKey, link)
+	pendingBotHits := r.findPendingHits(ctx, pendingBotHitsKey, link)
+
 	return domain.StatsResult{
-		Hits:      statsDoc.Hits,
+		LinkID:    statsDoc.ID.Hex(),
+		Hits:      statsDoc.Hits + pendingHits,
+		BotHits:   statsDoc.BotHits + pendingBotHits,
+		MaxHits:   statsDoc.MaxHits,
 		CreatedAt: statsDoc.CreatedAt.Time(),
 	}, nil
 }
 
-func (r *shortLinkRepo) setOriginalURLCache(ctx context.Context, key string, originalURL string) error {
-	cacheKey := genCacheKey(key, "originalURL")
+// findPendingHits returns the buffered hits of the link, failures are only logged.
+func (r *shortLinkRepo) findPendingHits(ctx context.Context, hashKey string, link domain.LinkRef) uint {
+	pendingHits, err := r.redis.HGet(ctx, hashKey, link.String()).Uint64()
+	if err != nil && err != redis.Nil {
+		r.logger.Warn("unable to fetch pending hits",
+			slog.String("key", link.String()),
+			slog.String("hash", hashKey),
+			slog.Any("error", err),
+		)
+	}
+
+	return uint(pendingHits)
+}
+
+// invalidateCache removes both cache entries of the link, failures are only logged.
+func (r *shortLinkRepo) invalidateCache(ctx context.Context, link domain.LinkRef) {
+	if err := r.deleteEntityCache(ctx, link); err != nil {
+		r.logger.Warn("unable to delete cache for short URL entity",
+			slog.String("key", link.String()),
+			slog.Any("error", err),
+		)
+	}
 
-	return r.redis.Set(ctx, cacheKey, originalURL, time.Hour*24).Err()
+	if err := r.deleteRedirectTargetCache(ctx, link); err != nil {
+		r.logger.Warn("unable to delete cache for redirect target",
+			slog.String("key", link.String()),
+			slog.Any("error", err),
+		)
+	}
 }
 
-func (r *shortLinkRepo) getOriginalURLCache(ctx context.Context, key string) (string, error) {
-	cacheKey := genCacheKey(key, "originalURL")
+func (r *shortLinkRepo) setRedirectTargetCache(ctx context.Context, link domain.LinkRef, target domain.RedirectTarget, expiresAt time.Time) error {
+	cacheKey := genCacheKey(link.String(), "redirectTarget")
+
+	ttl := cacheExpiration(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
 
-	originalURL, err := r.redis.Get(ctx, cacheKey).Result()
+	targetData, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Set(ctx, cacheKey, targetData, ttl).Err()
+}
+
+func (r *shortLinkRepo) getRedirectTargetCache(ctx context.Context, link domain.LinkRef) (*domain.RedirectTarget, error) {
+	cacheKey := genCacheKey(link.String(), "redirectTarget")
+
+	targetData, err := r.redis.Get(ctx, cacheKey).Result()
 	if err == redis.Nil {
-		return "", nil
+		return nil, nil
 	}
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	target := new(domain.RedirectTarget)
+	if err := json.Unmarshal([]byte(targetData), target); err != nil {
+		return nil, err
 	}
 
-	return originalURL, nil
+	return target, nil
 }
 
-func (r *shortLinkRepo) deleteOriginalURLCache(ctx context.Context, key string) error {
-	cacheKey := genCacheKey(key, "originalURL")
+func (r *shortLinkRepo) deleteRedirectTargetCache(ctx context.Context, link domain.LinkRef) error {
+	cacheKey := genCacheKey(link.String(), "redirectTarget")
 
 	return r.redis.Del(ctx, cacheKey).Err()
 }
 
 func (r *shortLinkRepo) setEntityCache(ctx context.Context, ent domain.ShortLink) error {
-	cacheKey := genCacheKey(ent.Key, "entity")
+	cacheKey := genCacheKey(ent.Ref().String(), "entity")
+
+	ttl := cacheExpiration(ent.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
 
 	entData, err := json.Marshal(ent)
 	if err != nil {
 		return err
 	}
 
-	return r.redis.Set(ctx, cacheKey, entData, time.Hour*24).Err()
+	return r.redis.Set(ctx, cacheKey, entData, ttl).Err()
 }
 
-func (r *shortLinkRepo) getEntityCache(ctx context.Context, key string) (*domain.ShortLink, error) {
-	cacheKey := genCacheKey(key, "entity")
+func (r *shortLinkRepo) getEntityCache(ctx context.Context, link domain.LinkRef) (*domain.ShortLink, error) {
+	cacheKey := genCacheKey(link.String(), "entity")
 
 	entData, err := r.redis.Get(ctx, cacheKey).Result()
 	if err == redis.Nil {
@@ -268,31 +617,119 @@ func (r *shortLinkRepo) getEntityCache(ctx context.Context, key string) (*domain
 	return ent, nil
 }
 
-func (r *shortLinkRepo) deleteEntityCache(ctx context.Context, key string) error {
-	cacheKey := genCacheKey(key, "entity")
+func (r *shortLinkRepo) deleteEntityCache(ctx context.Context, link domain.LinkRef) error {
+	cacheKey := genCacheKey(link.String(), "entity")
 
 	return r.redis.Del(ctx, cacheKey).Err()
 }
 
+// linkFilter matches a link by its domain and key, the nil domain also matches the links without the field.
+func linkFilter(link domain.LinkRef) bson.M {
+	if link.Domain == "" {
+		return bson.M{"domain": nil, "key": link.Key}
+	}
+
+	return bson.M{"domain": link.Domain, "key": link.Key}
+}
+
+// activeLinkFilter matches a link which is not in the trash.
+func activeLinkFilter(link domain.LinkRef) bson.M {
+	filter := linkFilter(link)
+	filter["deletedAt"] = nil
+
+	return filter
+}
+
 func genCacheKey(dataKey string, cacheName string) string {
 	return "shortener:" + cacheName + "#" + dataKey
 }
 
+// cacheExpiration returns the cache TTL which never outlives the link expiry.
+// A non-positive result means the link is already expired and must not be cached.
+func cacheExpiration(expiresAt time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return cacheTTL
+	}
+
+	return min(cacheTTL, time.Until(expiresAt))
+}
+
+func newRedirectTarget(entity domain.ShortLink) domain.RedirectTarget {
+	return domain.RedirectTarget{
+		LinkID:          entity.ID,
+		OriginalURL:     entity.OriginalURL,
+		ExpiresAt:       entity.ExpiresAt,
+		MaxHits:         entity.MaxHits,
+		PasswordHash:    entity.PasswordHash,
+		RedirectStatus:  entity.RedirectStatus,
+		QueryForwarding: entity.QueryForwarding,
+		ForwardPath:     entity.ForwardPath,
+		Disabled:        entity.Disabled,
+	}
+}
+
 func fromEntityToDocument(entity domain.ShortLink) shortLinkDoc {
 	return shortLinkDoc{
-		Key:         entity.Key,
-		OriginalURL: entity.OriginalURL,
-		Hits:        entity.Hits,
-		CreatedAt:   primitive.NewDateTimeFromTime(entity.CreatedAt),
+		Domain:          entity.Domain,
+		Key:             entity.Key,
+		OriginalURL:     entity.OriginalURL,
+		Host:            extractHost(entity.OriginalURL),
+		Hits:            entity.Hits,
+		CreatedAt:       primitive.NewDateTimeFromTime(entity.CreatedAt),
+		ExpiresAt:       toOptionalDateTime(entity.ExpiresAt),
+		MaxHits:         entity.MaxHits,
+		PasswordHash:    entity.PasswordHash,
+		RedirectStatus:  entity.RedirectStatus,
+		QueryForwarding: string(entity.QueryForwarding),
+		ForwardPath:     entity.ForwardPath,
+		DeletedAt:       toOptionalDateTime(entity.DeletedAt),
+		DeletedBy:       entity.DeletedBy,
+		Disabled:        entity.Disabled,
 	}
 }
 
 func fromDocumentToEntity(model shortLinkDoc) domain.ShortLink {
 	return domain.ShortLink{
-		ID:          model.ID.Hex(),
-		Key:         model.Key,
-		OriginalURL: model.OriginalURL,
-		Hits:        model.Hits,
-		CreatedAt:   model.CreatedAt.Time(),
+		ID:              model.ID.Hex(),
+		Domain:          model.Domain,
+		Key:             model.Key,
+		OriginalURL:     model.OriginalURL,
+		Hits:            model.Hits,
+		CreatedAt:       model.CreatedAt.Time(),
+		ExpiresAt:       fromOptionalDateTime(model.ExpiresAt),
+		MaxHits:         model.MaxHits,
+		PasswordHash:    model.PasswordHash,
+		RedirectStatus:  model.RedirectStatus,
+		QueryForwarding: domain.QueryForwarding(model.QueryForwarding),
+		ForwardPath:     model.ForwardPath,
+		DeletedAt:       fromOptionalDateTime(model.DeletedAt),
+		DeletedBy:       model.DeletedBy,
+		Disabled:        model.Disabled,
+	}
+}
+
+func extractHost(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(parsedURL.Hostname())
+}
+
+func toOptionalDateTime(t time.Time) *primitive.DateTime {
+	if t.IsZero() {
+		return nil
 	}
+
+	dt := primitive.NewDateTimeFromTime(t)
+	return &dt
+}
+
+func fromOptionalDateTime(dt *primitive.DateTime) time.Time {
+	if dt == nil {
+		return time.Time{}
+	}
+
+	return dt.Time()
 }
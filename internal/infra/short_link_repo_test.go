@@ -0,0 +1,113 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestFindStatsAddsPendingHits(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	linkID := primitive.NewObjectID()
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		pendingHits  string
+		expectedHits uint
+	}{
+		{name: "Without Pending Hits", expectedHits: 5},
+		{name: "With Pending Hits", pendingHits: "3", expectedHits: 8},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			redisServer, redisClient := newTestRedis(mt.T)
+			if tt.pendingHits != "" {
+				redisServer.HSet(pendingHitsKey, "abc", tt.pendingHits)
+			}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "shortener.short_links", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: linkID},
+				{Key: "hits", Value: 5},
+				{Key: "maxHits", Value: 10},
+				{Key: "createdAt", Value: primitive.NewDateTimeFromTime(createdAt)},
+			}))
+
+			r := &shortLinkRepo{
+				logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+				collection: mt.Coll,
+				redis:      redisClient,
+				bufferHits: true,
+			}
+
+			stats, err := r.FindStats(context.Background(), domain.LinkRef{Key: "abc"})
+			if err != nil {
+				mt.Fatalf("FindStats() error = %v; want nil", err)
+			}
+			if stats.Hits != tt.expectedHits {
+				mt.Errorf("FindStats() hits = %d; want %d", stats.Hits, tt.expectedHits)
+			}
+			if stats.MaxHits != 10 || stats.LinkID != linkID.Hex() || !stats.CreatedAt.Equal(createdAt) {
+				mt.Errorf("FindStats() = %+v; want the stored max hits, link ID and creation time", stats)
+			}
+		})
+	}
+}
+
+func TestIncreaseHitsLimitCountsPendingHits(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name          string
+		pendingHits   string
+		expectedAdded int64
+	}{
+		{name: "Without Pending Hits", expectedAdded: 0},
+		{name: "Hits Buffered Before The Limit", pendingHits: "4", expectedAdded: 4},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			redisServer, redisClient := newTestRedis(mt.T)
+			if tt.pendingHits != "" {
+				redisServer.HSet(pendingHitsKey, "abc", tt.pendingHits)
+			}
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+				{Key: "hits", Value: 3},
+				{Key: "maxHits", Value: 10},
+			}}))
+
+			r := &shortLinkRepo{
+				logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+				collection: mt.Coll,
+				redis:      redisClient,
+				bufferHits: true,
+			}
+
+			if err := r.IncreaseHits(context.Background(), domain.LinkRef{Key: "abc"}, true); err != nil {
+				mt.Fatalf("IncreaseHits() error = %v; want nil", err)
+			}
+
+			// the stored hits plus the pending ones must stay below the limit
+			started := mt.GetStartedEvent()
+			if started == nil || started.CommandName != "findAndModify" {
+				mt.Fatalf("started command = %v; want findAndModify", started)
+			}
+			added := started.Command.Lookup("query", "$or").Array().Index(1).Value().Document().
+				Lookup("$expr", "$lt").Array().Index(0).Value().Document().
+				Lookup("$add").Array().Index(1).Value().AsInt64()
+			if added != tt.expectedAdded {
+				mt.Errorf("pending hits added to the stored hits = %d; want %d", added, tt.expectedAdded)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// purgeBatchSize is the number of links removed per round trip of the purge.
+const purgeBatchSize = 500
+
+func (r *shortLinkRepo) RestoreOne(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	update := bson.M{"$unset": bson.M{"deletedAt": "", "deletedBy": ""}}
+
+	doc := new(shortLinkDoc)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, deletedLinkFilter(link), update, opts).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return domain.ShortLink{}, domain.ErrShortLinkNotFound
+	}
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	// a lookup racing with the deletion may have cached the link, it is read again from MongoDB
+	r.invalidateCache(ctx, link)
+
+	return fromDocumentToEntity(*doc), nil
+}
+
+func (r *shortLinkRepo) PurgeOne(ctx context.Context, link domain.LinkRef) error {
+	res, err := r.collection.DeleteOne(ctx, deletedLinkFilter(link))
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrShortLinkNotFound
+	}
+
+	r.deletePendingHits(ctx, []domain.LinkRef{link})
+
+	return nil
+}
+
+func (r *shortLinkRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	deletedAtFilter := bson.M{"$lt": primitive.NewDateTimeFromTime(deletedBefore)}
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "key": 1, "domain": 1}).
+		SetBatchSize(purgeBatchSize)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"deletedAt": deletedAtFilter}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("find deleted short links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var purged int64
+	ids := make([]primitive.ObjectID, 0, purgeBatchSize)
+	links := make([]domain.LinkRef, 0, purgeBatchSize)
+	for cursor.Next(ctx) {
+		var doc shortLinkDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return purged, fmt.Errorf("decode short link: %w", err)
+		}
+
+		ids = append(ids, doc.ID)
+		links = append(links, domain.LinkRef{Domain: doc.Domain, Key: doc.Key})
+		if len(ids) == purgeBatchSize {
+			count, err := r.purgeBatch(ctx, ids, links, deletedAtFilter)
+			purged += count
+			if err != nil {
+				return purged, err
+			}
+			ids, links = ids[:0], links[:0]
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return purged, fmt.Errorf("iterate deleted short links: %w", err)
+	}
+
+	count, err := r.purgeBatch(ctx, ids, links, deletedAtFilter)
+
+	return purged + count, err
+}
+
+// purgeBatch removes the links found by the purge. The deletion time is matched again,
+// so a link restored since it was found is kept.
+func (r *shortLinkRepo) purgeBatch(ctx context.Context, ids []primitive.ObjectID, links []domain.LinkRef, deletedAtFilter bson.M) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	res, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "deletedAt": deletedAtFilter})
+	if err != nil {
+		return 0, fmt.Errorf("delete short links: %w", err)
+	}
+
+	r.deletePendingHits(ctx, links)
+
+	return res.DeletedCount, nil
+}
+
+// deletePendingHits drops the buffered hits of the removed links, they have nowhere to go.
+// Failures are only logged.
+func (r *shortLinkRepo) deletePendingHits(ctx context.Context, links []domain.LinkRef) {
+	fields := make([]string, 0, len(links))
+	for _, link := range links {
+		fields = append(fields, link.String())
+	}
+
+	for _, counter := range pendingCounters {
+		if err := r.redis.HDel(ctx, counter.hashKey, fields...).Err(); err != nil {
+			r.logger.Warn("unable to delete pending hits",
+				slog.Int("links", len(links)),
+				slog.String("field", counter.field),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// deletedLinkFilter matches a link which is in the trash.
+func deletedLinkFilter(link domain.LinkRef) bson.M {
+	filter := linkFilter(link)
+	filter["deletedAt"] = bson.M{"$ne": nil}
+
+	return filter
+}
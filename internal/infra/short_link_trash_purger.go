@@ -0,0 +1,77 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const trashPurgeTimeout = time.Minute
+
+// ShortLinkTrashPurger periodically removes the links which are in the trash for longer than the purge period.
+// Several instances may purge at the same time, a link is removed only once.
+type ShortLinkTrashPurger struct {
+	logger     *slog.Logger
+	repo       domain.ShortLinkRepo
+	purgeAfter time.Duration
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+func NewShortLinkTrashPurger(logger *slog.Logger, repo domain.ShortLinkRepo, purgeAfter time.Duration, interval time.Duration) *ShortLinkTrashPurger {
+	p := &ShortLinkTrashPurger{
+		logger:     logger,
+		repo:       repo,
+		purgeAfter: purgeAfter,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go p.run(interval)
+
+	return p
+}
+
+// Close stops the periodic purge, waiting for the running one.
+func (p *ShortLinkTrashPurger) Close(ctx context.Context) error {
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *ShortLinkTrashPurger) run(interval time.Duration) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), trashPurgeTimeout)
+			purged, err := p.repo.PurgeDeleted(ctx, time.Now().Add(-p.purgeAfter))
+			cancel()
+			if err != nil {
+				p.logger.Error("unable to purge the trash", slog.Int64("purged", purged), slog.Any("error", err))
+				continue
+			}
+			if purged > 0 {
+				p.logger.Info("links purged from the trash", slog.Int64("purged", purged))
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func newTestTrashRepo(mt *mtest.T) (*shortLinkRepo, func(key string) bool) {
+	redisServer, redisClient := newTestRedis(mt.T)
+	r := &shortLinkRepo{
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		collection: mt.Coll,
+		redis:      redisClient,
+	}
+
+	return r, redisServer.Exists
+}
+
+func TestDeleteOneMovesLinkToTrash(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("Active Link", func(mt *mtest.T) {
+		r, cached := newTestTrashRepo(mt)
+		entityKey := genCacheKey("abc", "entity")
+		targetKey := genCacheKey("abc", "redirectTarget")
+		r.redis.Set(context.Background(), entityKey, "{}", 0)
+		r.redis.Set(context.Background(), targetKey, "{}", 0)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "key", Value: "abc"},
+			{Key: "originalURL", Value: "https://example.com"},
+			{Key: "deletedBy", Value: "admin"},
+		}}))
+
+		ent, err := r.DeleteOne(context.Background(), domain.LinkRef{Key: "abc"}, "admin")
+		if err != nil {
+			mt.Fatalf("DeleteOne() error = %v; want nil", err)
+		}
+		if ent.Key != "abc" || ent.DeletedBy != "admin" {
+			mt.Errorf("DeleteOne() = %+v; want the deleted link", ent)
+		}
+
+		// only a link which is not in the trash is matched, and the document is kept
+		started := mt.GetStartedEvent()
+		if started == nil || started.CommandName != "findAndModify" {
+			mt.Fatalf("started command = %v; want findAndModify", started)
+		}
+		if deletedAt := started.Command.Lookup("query", "deletedAt"); deletedAt.Type != bson.TypeNull {
+			mt.Errorf("filter deletedAt = %v; want null", deletedAt)
+		}
+		if _, ok := started.Command.Lookup("update", "$set", "deletedAt").DateTimeOK(); !ok {
+			mt.Errorf("update = %v; want deletedAt set", started.Command.Lookup("update"))
+		}
+		if deletedBy := started.Command.Lookup("update", "$set", "deletedBy").StringValue(); deletedBy != "admin" {
+			mt.Errorf("deletedBy = %q; want %q", deletedBy, "admin")
+		}
+		if cached(entityKey) || cached(targetKey) {
+			mt.Errorf("cache entries kept after DeleteOne(); want them deleted")
+		}
+	})
+
+	mt.Run("Missing Or Deleted Link", func(mt *mtest.T) {
+		r, _ := newTestTrashRepo(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}))
+
+		if _, err := r.DeleteOne(context.Background(), domain.LinkRef{Key: "abc"}, ""); err != domain.ErrShortLinkNotFound {
+			mt.Errorf("DeleteOne() error = %v; want %v", err, domain.ErrShortLinkNotFound)
+		}
+	})
+}
+
+func TestRestoreOne(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("Deleted Link", func(mt *mtest.T) {
+		r, cached := newTestTrashRepo(mt)
+		targetKey := genCacheKey("abc", "redirectTarget")
+		r.redis.Set(context.Background(), targetKey, "{}", 0)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "key", Value: "abc"},
+			{Key: "originalURL", Value: "https://example.com"},
+		}}))
+
+		ent, err := r.RestoreOne(context.Background(), domain.LinkRef{Key: "abc"})
+		if err != nil {
+			mt.Fatalf("RestoreOne() error = %v; want nil", err)
+		}
+		if ent.Key != "abc" || ent.OriginalURL != "https://example.com" {
+			mt.Errorf("RestoreOne() = %+v; want the restored link", ent)
+		}
+
+		// the restored document is returned, and only a link in the trash is matched
+		started := mt.GetStartedEvent()
+		if started == nil || started.CommandName != "findAndModify" {
+			mt.Fatalf("started command = %v; want findAndModify", started)
+		}
+		if returnNew, ok := started.Command.Lookup("new").BooleanOK(); !ok || !returnNew {
+			mt.Errorf("findAndModify new = %v; want true", started.Command.Lookup("new"))
+		}
+		if _, err := started.Command.LookupErr("query", "deletedAt", "$ne"); err != nil {
+			mt.Errorf("filter = %v; want deletedAt $ne null", started.Command.Lookup("query"))
+		}
+		if cached(targetKey) {
+			mt.Errorf("redirect target cache kept after RestoreOne(); want it deleted")
+		}
+	})
+
+	mt.Run("Link Not In Trash", func(mt *mtest.T) {
+		r, _ := newTestTrashRepo(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}))
+
+		if _, err := r.RestoreOne(context.Background(), domain.LinkRef{Key: "abc"}); err != domain.ErrShortLinkNotFound {
+			mt.Errorf("RestoreOne() error = %v; want %v", err, domain.ErrShortLinkNotFound)
+		}
+	})
+}
+
+func TestPurgeOne(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name        string
+		deleted     int
+		expectedErr error
+	}{
+		{name: "Deleted Link", deleted: 1, expectedErr: nil},
+		{name: "Link Not In Trash", deleted: 0, expectedErr: domain.ErrShortLinkNotFound},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			r, _ := newTestTrashRepo(mt)
+			r.redis.HSet(context.Background(), pendingHitsKey, "abc", 3)
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: tt.deleted}))
+
+			err := r.PurgeOne(context.Background(), domain.LinkRef{Key: "abc"})
+			if err != tt.expectedErr {
+				mt.Fatalf("PurgeOne() error = %v; want %v", err, tt.expectedErr)
+			}
+
+			// an active link is never purged, and the pending hits of a purged one are dropped
+			started := mt.GetStartedEvent()
+			if started == nil || started.CommandName != "delete" {
+				mt.Fatalf("started command = %v; want delete", started)
+			}
+			query := started.Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q").Document()
+			if _, err := query.LookupErr("deletedAt", "$ne"); err != nil {
+				mt.Errorf("filter = %v; want deletedAt $ne null", query)
+			}
+			pendingKept := r.redis.HExists(context.Background(), pendingHitsKey, "abc").Val()
+			if pendingKept != (tt.expectedErr != nil) {
+				mt.Errorf("pending hits kept = %t; want %t", pendingKept, tt.expectedErr != nil)
+			}
+		})
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("Links Deleted Before The Cutoff", func(mt *mtest.T) {
+		r, _ := newTestTrashRepo(mt)
+		r.redis.HSet(context.Background(), pendingHitsKey, "abc", 3, "other", 1)
+		r.redis.HSet(context.Background(), pendingBotHitsKey, "example.org/def", 2)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "shortener.short_links", mtest.FirstBatch,
+				bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "key", Value: "abc"}},
+				bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "key", Value: "def"}, {Key: "domain", Value: "example.org"}},
+			),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}),
+		)
+
+		cutoff := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		purged, err := r.PurgeDeleted(context.Background(), cutoff)
+		if err != nil {
+			mt.Fatalf("PurgeDeleted() error = %v; want nil", err)
+		}
+		if purged != 2 {
+			mt.Errorf("PurgeDeleted() = %d; want 2", purged)
+		}
+
+		// the deletion time is matched again, so a link restored in between is kept
+		mt.GetStartedEvent()
+		started := mt.GetStartedEvent()
+		if started == nil || started.CommandName != "delete" {
+			mt.Fatalf("started command = %v; want delete", started)
+		}
+		query := started.Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q").Document()
+		if lt := query.Lookup("deletedAt", "$lt").Time(); !lt.Equal(cutoff) {
+			mt.Errorf("filter deletedAt $lt = %v; want %v", lt, cutoff)
+		}
+
+		pending := r.redis.HKeys(context.Background(), pendingHitsKey).Val()
+		if len(pending) != 1 || pending[0] != "other" {
+			mt.Errorf("pending hits = %v; want only the hits of the kept link", pending)
+		}
+		if r.redis.Exists(context.Background(), pendingBotHitsKey).Val() != 0 {
+			mt.Errorf("pending bot hits kept after PurgeDeleted(); want them dropped")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package infra
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// the HyperLogLogs older than the retention are dropped by Redis
+	uniqueVisitorsRetention   = 400 * 24 * time.Hour
+	uniqueVisitorsDayLayout   = "2006-01-02"
+	uniqueVisitorsMonthLayout = "2006-01"
+)
+
+var _ domain.UniqueVisitorRepo = (*uniqueVisitorRepo)(nil)
+
+// uniqueVisitorRepo keeps one Redis HyperLogLog per link and UTC day, and one per link and UTC month,
+// so the distinct visitors of any range of days can be counted by merging them.
+type uniqueVisitorRepo struct {
+	logger *slog.Logger
+	redis  *redis.Client
+}
+
+func NewUniqueVisitorRepository(logger *slog.Logger, redis *redis.Client) *uniqueVisitorRepo {
+	return &uniqueVisitorRepo{
+		logger: logger,
+		redis:  redis,
+	}
+}
+
+func (r *uniqueVisitorRepo) Add(ctx context.Context, linkID string, visitorID string, at time.Time) error {
+	dayKey := genUniqueVisitorsKey(linkID, at.UTC().Format(uniqueVisitorsDayLayout))
+	monthKey := genUniqueVisitorsKey(linkID, at.UTC().Format(uniqueVisitorsMonthLayout))
+
+	pipe := r.redis.Pipeline()
+	pipe.PFAdd(ctx, dayKey, visitorID)
+	pipe.ExpireNX(ctx, dayKey, uniqueVisitorsRetention)
+	pipe.PFAdd(ctx, monthKey, visitorID)
+	pipe.ExpireNX(ctx, monthKey, uniqueVisitorsRetention)
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+func (r *uniqueVisitorRepo) Count(ctx context.Context, linkID string, from time.Time, to time.Time) (uint, error) {
+	if oldest := time.Now().Add(-uniqueVisitorsRetention); from.Before(oldest) {
+		from = oldest
+	}
+
+	keys := uniqueVisitorsKeys(linkID, from, to)
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	count, err := r.redis.PFCount(ctx, keys...).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(count), nil
+}
+
+// uniqueVisitorsKeys returns the keys covering the days of the range, the months within the range are merged
+// through their rollup, so a range of the whole retention needs about 75 keys instead of 400.
+func uniqueVisitorsKeys(linkID string, from time.Time, to time.Time) []string {
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC()
+
+	var keys []string
+	for day := from; !day.After(to); {
+		nextMonth := day.AddDate(0, 1, 0)
+		if day.Day() == 1 && !nextMonth.After(to) {
+			keys = append(keys, genUniqueVisitorsKey(linkID, day.Format(uniqueVisitorsMonthLayout)))
+			day = nextMonth
+			continue
+		}
+
+		keys = append(keys, genUniqueVisitorsKey(linkID, day.Format(uniqueVisitorsDayLayout)))
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return keys
+}
+
+// genUniqueVisitorsKey returns the key of a day or a month, the link ID keeps the visitors of a reused key apart.
+func genUniqueVisitorsKey(linkID string, period string) string {
+	return genCacheKey(linkID+":"+period, "linkUniqueVisitors")
+}
@@ -0,0 +1,60 @@
+package infra
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestUniqueVisitorsKeys(t *testing.T) {
+	const linkID = "65f1a2b3c4d5e6f708091a2b"
+	key := func(period string) string { return genUniqueVisitorsKey(linkID, period) }
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected []string
+	}{
+		{
+			name:     "Same Day",
+			from:     time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC),
+			expected: []string{key("2026-03-10")},
+		},
+		{
+			name:     "Partial Month",
+			from:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC),
+			expected: []string{key("2026-03-01"), key("2026-03-02"), key("2026-03-03")},
+		},
+		{
+			name: "Whole Months",
+			from: time.Date(2026, 1, 30, 12, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC),
+			expected: []string{
+				key("2026-01-30"), key("2026-01-31"), key("2026-02"), key("2026-03"), key("2026-04-01"), key("2026-04-02"),
+			},
+		},
+		{
+			name:     "Reversed Range",
+			from:     time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uniqueVisitorsKeys(linkID, tt.from, tt.to); !slices.Equal(got, tt.expected) {
+				t.Errorf("uniqueVisitorsKeys() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+
+	// the whole retention is merged through the monthly rollups
+	to := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	if got := uniqueVisitorsKeys(linkID, to.Add(-uniqueVisitorsRetention), to); len(got) > 80 {
+		t.Errorf("uniqueVisitorsKeys() of the retention = %d keys; want at most 80", len(got))
+	}
+}
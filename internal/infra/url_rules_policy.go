@@ -0,0 +1,66 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"sync/atomic"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/domainlist"
+)
+
+var _ domain.URLPolicy = (*URLRulesPolicy)(nil)
+
+// URLRulesPolicy rejects the URLs whose host is denied by the domain rules of a file.
+// The file can be reloaded while the service runs, the requests keep using the previous rules meanwhile.
+type URLRulesPolicy struct {
+	logger *slog.Logger
+	path   string
+	rules  atomic.Pointer[domainlist.List]
+}
+
+func NewURLRulesPolicy(logger *slog.Logger, path string) (*URLRulesPolicy, error) {
+	p := &URLRulesPolicy{
+		logger: logger,
+		path:   path,
+	}
+
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Reload reads the rules file again. The previous rules are kept when the file is invalid.
+func (p *URLRulesPolicy) Reload() error {
+	file, err := os.Open(p.path)
+	if err != nil {
+		return fmt.Errorf("open url rules file: %w", err)
+	}
+	defer file.Close()
+
+	rules, err := domainlist.Parse(file)
+	if err != nil {
+		return fmt.Errorf("parse url rules file: %w", err)
+	}
+
+	p.rules.Store(rules)
+	p.logger.Info("url rules loaded",
+		slog.String("path", p.path),
+		slog.Int("rules", rules.Len()),
+	)
+
+	return nil
+}
+
+func (p *URLRulesPolicy) Check(_ context.Context, _ string, destination *url.URL) error {
+	if p.rules.Load().Match(destination.Hostname()) == domainlist.Deny {
+		return domain.ErrDomainNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+var _ domain.APIKeyUsecase = (*apiKeyUsecase)(nil)
+
+const (
+	apiKeyTokenPrefix = "usk_"
+	apiKeyTokenBytes  = 32
+	// length of the displayed token prefix, including apiKeyTokenPrefix
+	apiKeyDisplayPrefixLength = 12
+	apiKeyNameMaxLength       = 64
+)
+
+type apiKeyUsecase struct {
+	logger     *slog.Logger
+	apiKeyRepo domain.APIKeyRepo
+}
+
+func NewAPIKeyUsecase(logger *slog.Logger, apiKeyRepository domain.APIKeyRepo) *apiKeyUsecase {
+	return &apiKeyUsecase{
+		logger:     logger,
+		apiKeyRepo: apiKeyRepository,
+	}
+}
+
+func (u *apiKeyUsecase) Create(ctx context.Context, createInput domain.CreateAPIKeyAction) (domain.CreateAPIKeyResult, error) {
+	name := strings.TrimSpace(createInput.Name)
+	if name == "" || len(name) > apiKeyNameMaxLength {
+		return domain.CreateAPIKeyResult{}, domain.ErrInvalidAPIKeyName
+	}
+
+	if len(createInput.Scopes) == 0 {
+		return domain.CreateAPIKeyResult{}, domain.ErrInvalidAPIKeyScope
+	}
+	for _, scope := range createInput.Scopes {
+		if !slices.Contains(domain.AllScopes, scope) {
+			return domain.CreateAPIKeyResult{}, domain.ErrInvalidAPIKeyScope
+		}
+	}
+	scopes := slices.Clone(createInput.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	token, err := genAPIKeyToken()
+	if err != nil {
+		return domain.CreateAPIKeyResult{}, fmt.Errorf("APIKeyUsecase.Create: generate token: %w", err)
+	}
+
+	key := domain.APIKey{
+		Name:      name,
+		Prefix:    token[:apiKeyDisplayPrefixLength],
+		Hash:      hashAPIKeyToken(token),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}
+
+	id, err := u.apiKeyRepo.InsertOne(ctx, key)
+	if err != nil {
+		return domain.CreateAPIKeyResult{}, fmt.Errorf("APIKeyUsecase.Create: insert: %w", err)
+	}
+	key.ID = id
+
+	return domain.CreateAPIKeyResult{Key: key, Token: token}, nil
+}
+
+func (u *apiKeyUsecase) List(ctx context.Context) ([]domain.APIKey, error) {
+	keys, err := u.apiKeyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("APIKeyUsecase.List: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (u *apiKeyUsecase) Revoke(ctx context.Context, id string) error {
+	err := u.apiKeyRepo.Revoke(ctx, id, time.Now())
+	if err != nil {
+		if err == domain.ErrAPIKeyNotFound {
+			return domain.ErrAPIKeyNotFound
+		}
+
+		return fmt.Errorf("APIKeyUsecase.Revoke (id: %s): %w", id, err)
+	}
+
+	return nil
+}
+
+func genAPIKeyToken() (string, error) {
+	b := make([]byte, apiKeyTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return apiKeyTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIKeyToken uses a fast hash, the tokens are random, so they cannot be guessed from it.
+func hashAPIKeyToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
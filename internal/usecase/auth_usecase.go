@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+var _ domain.AuthUsecase = (*authUsecase)(nil)
+
+const (
+	// owner of the requests authenticated by the shared API_SECRET
+	legacySecretOwner = "api-secret"
+	// the last use of a key is written at most once per interval, so most authentications are read-only
+	apiKeyLastUsedInterval = time.Minute
+)
+
+type authUsecase struct {
+	logger     *slog.Logger
+	apiKeyRepo domain.APIKeyRepo
+	// nil when JWT authentication is disabled
+	tokenVerifier domain.TokenVerifier
+	// the shared secret of older deployments, accepted as an admin key when not empty
+	legacySecret string
+}
+
+func NewAuthUsecase(
+	logger *slog.Logger,
+	apiKeyRepository domain.APIKeyRepo,
+	tokenVerifier domain.TokenVerifier,
+	legacySecret string,
+) *authUsecase {
+	return &authUsecase{
+		logger:        logger,
+		apiKeyRepo:    apiKeyRepository,
+		tokenVerifier: tokenVerifier,
+		legacySecret:  legacySecret,
+	}
+}
+
+func (u *authUsecase) Authenticate(ctx context.Context, token string) (domain.Principal, error) {
+	if u.legacySecret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(u.legacySecret)) == 1 {
+		return domain.Principal{
+			Owner:  legacySecretOwner,
+			Method: domain.AuthMethodAPIKey,
+			Scopes: []domain.Scope{domain.ScopeAdmin},
+		}, nil
+	}
+
+	if strings.HasPrefix(token, apiKeyTokenPrefix) {
+		return u.authenticateAPIKey(ctx, token)
+	}
+
+	if u.tokenVerifier != nil && strings.Count(token, ".") == 2 {
+		return u.authenticateJWT(ctx, token)
+	}
+
+	return domain.Principal{}, domain.ErrInvalidToken
+}
+
+func (u *authUsecase) authenticateAPIKey(ctx context.Context, token string) (domain.Principal, error) {
+	// the lookup is done by hash, so the comparison does not leak the token through timing
+	key, err := u.apiKeyRepo.FindByHash(ctx, hashAPIKeyToken(token))
+	if err != nil {
+		if err == domain.ErrAPIKeyNotFound {
+			return domain.Principal{}, domain.ErrInvalidToken
+		}
+
+		return domain.Principal{}, fmt.Errorf("AuthUsecase.Authenticate: find api key: %w", err)
+	}
+
+	if key.IsRevoked() {
+		return domain.Principal{}, domain.ErrInvalidToken
+	}
+
+	now := time.Now()
+	if now.Sub(key.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := u.apiKeyRepo.UpdateLastUsed(ctx, key.ID, now); err != nil {
+			u.logger.Warn("failed to update the api key last use, continue",
+				slog.String("id", key.ID),
+				slog.Any("err", err),
+			)
+		}
+	}
+
+	return domain.Principal{
+		Owner:  key.ID,
+		Method: domain.AuthMethodAPIKey,
+		Scopes: key.Scopes,
+	}, nil
+}
+
+func (u *authUsecase) authenticateJWT(ctx context.Context, token string) (domain.Principal, error) {
+	claims, err := u.tokenVerifier.Verify(ctx, token)
+	if err != nil {
+		if err == domain.ErrInvalidToken {
+			return domain.Principal{}, domain.ErrInvalidToken
+		}
+
+		return domain.Principal{}, fmt.Errorf("AuthUsecase.Authenticate: verify token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return domain.Principal{}, domain.ErrInvalidToken
+	}
+
+	// the identity provider may issue scopes of other services, only the known ones are granted
+	scopes := make([]domain.Scope, 0, len(claims.Scopes))
+	for _, value := range claims.Scopes {
+		if scope := domain.Scope(value); slices.Contains(domain.AllScopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return domain.Principal{
+		Owner:  claims.Subject,
+		Method: domain.AuthMethodJWT,
+		Scopes: scopes,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// fakeAPIKeyRepo knows the keys by the hash of their token.
+type fakeAPIKeyRepo struct {
+	domain.APIKeyRepo
+	keys map[string]domain.APIKey
+}
+
+func (r *fakeAPIKeyRepo) FindByHash(_ context.Context, hash string) (domain.APIKey, error) {
+	key, ok := r.keys[hash]
+	if !ok {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *fakeAPIKeyRepo) UpdateLastUsed(context.Context, string, time.Time) error {
+	return nil
+}
+
+// fakeTokenVerifier accepts the tokens it knows the claims of.
+type fakeTokenVerifier struct {
+	claims map[string]domain.TokenClaims
+}
+
+func (v fakeTokenVerifier) Verify(_ context.Context, token string) (domain.TokenClaims, error) {
+	claims, ok := v.claims[token]
+	if !ok {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	const (
+		legacySecret   = "legacy-secret"
+		activeToken    = "usk_active"
+		revokedToken   = "usk_revoked"
+		jwtToken       = "header.payload.signature"
+		foreignJWT     = "header.foreign.signature"
+		subjectlessJWT = "header.anonymous.signature"
+	)
+
+	repo := &fakeAPIKeyRepo{keys: map[string]domain.APIKey{
+		hashAPIKeyToken(activeToken): {
+			ID:     "key-1",
+			Name:   "ci",
+			Scopes: []domain.Scope{domain.ScopeLinksCreate},
+		},
+		hashAPIKeyToken(revokedToken): {
+			ID:        "key-2",
+			Name:      "old",
+			Scopes:    []domain.Scope{domain.ScopeLinksCreate},
+			RevokedAt: time.Now().Add(-time.Hour),
+		},
+	}}
+	verifier := fakeTokenVerifier{claims: map[string]domain.TokenClaims{
+		jwtToken:       {Subject: "user-1", Scopes: []string{"links:read", "links:stats"}},
+		foreignJWT:     {Subject: "user-2", Scopes: []string{"billing:read", "links:read", "openid"}},
+		subjectlessJWT: {Scopes: []string{"links:read"}},
+	}}
+	u := NewAuthUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, verifier, legacySecret)
+
+	tests := []struct {
+		name              string
+		token             string
+		expectedErr       error
+		expectedPrincipal domain.Principal
+	}{
+		{
+			name:  "Legacy Secret",
+			token: legacySecret,
+			expectedPrincipal: domain.Principal{
+				Owner:  "api-secret",
+				Method: domain.AuthMethodAPIKey,
+				Scopes: []domain.Scope{domain.ScopeAdmin},
+			},
+		},
+		{
+			name:  "API Key",
+			token: activeToken,
+			expectedPrincipal: domain.Principal{
+				Owner:  "key-1",
+				Method: domain.AuthMethodAPIKey,
+				Scopes: []domain.Scope{domain.ScopeLinksCreate},
+			},
+		},
+		{name: "Revoked API Key", token: revokedToken, expectedErr: domain.ErrInvalidToken},
+		{name: "Unknown API Key", token: "usk_unknown", expectedErr: domain.ErrInvalidToken},
+		{
+			name:  "JWT",
+			token: jwtToken,
+			expectedPrincipal: domain.Principal{
+				Owner:  "user-1",
+				Method: domain.AuthMethodJWT,
+				Scopes: []domain.Scope{domain.ScopeLinksRead, domain.ScopeLinksStats},
+			},
+		},
+		{
+			name:  "JWT Unknown Scopes Dropped",
+			token: foreignJWT,
+			expectedPrincipal: domain.Principal{
+				Owner:  "user-2",
+				Method: domain.AuthMethodJWT,
+				Scopes: []domain.Scope{domain.ScopeLinksRead},
+			},
+		},
+		{name: "JWT Without Subject", token: subjectlessJWT, expectedErr: domain.ErrInvalidToken},
+		{name: "JWT Rejected By Verifier", token: "header.forged.signature", expectedErr: domain.ErrInvalidToken},
+		{name: "Unknown Token Format", token: "something-else", expectedErr: domain.ErrInvalidToken},
+		{name: "Empty Token", token: "", expectedErr: domain.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := u.Authenticate(context.Background(), tt.token)
+			if err != tt.expectedErr {
+				t.Fatalf("Authenticate(%q) error = %v; want %v", tt.token, err, tt.expectedErr)
+			}
+			if principal.Owner != tt.expectedPrincipal.Owner ||
+				principal.Method != tt.expectedPrincipal.Method ||
+				!slices.Equal(principal.Scopes, tt.expectedPrincipal.Scopes) {
+				t.Errorf("Authenticate(%q) = %+v; want %+v", tt.token, principal, tt.expectedPrincipal)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutLegacySecret(t *testing.T) {
+	u := NewAuthUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), &fakeAPIKeyRepo{}, nil, "")
+
+	// an empty secret must not turn an empty token into an admin key
+	if _, err := u.Authenticate(context.Background(), ""); err != domain.ErrInvalidToken {
+		t.Errorf("Authenticate(%q) error = %v; want %v", "", err, domain.ErrInvalidToken)
+	}
+}
+
+func TestAuthenticateWithoutTokenVerifier(t *testing.T) {
+	u := NewAuthUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), &fakeAPIKeyRepo{}, nil, "")
+
+	// a JWT is not accepted when the JWT authentication is disabled
+	token := "header.payload.signature"
+	if _, err := u.Authenticate(context.Background(), token); err != domain.ErrInvalidToken {
+		t.Errorf("Authenticate(%q) error = %v; want %v", token, err, domain.ErrInvalidToken)
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	aliasMinLength = 3
+	aliasMaxLength = 64
+)
+
+// reservedAliases holds the keys that collide with existing routes or are
+// likely to be confused with them. The comparison is case-insensitive.
+var reservedAliases = map[string]struct{}{
+	"api":               {},
+	"health":            {},
+	"openapi-spec.yaml": {},
+	"admin":             {},
+	"static":            {},
+	"favicon.ico":       {},
+	"robots.txt":        {},
+}
+
+// validateAlias checks that the custom alias has an allowed length and charset
+// and that it is not one of the reserved words.
+func validateAlias(alias string) error {
+	if len(alias) < aliasMinLength || len(alias) > aliasMaxLength {
+		return domain.ErrInvalidAlias
+	}
+
+	for _, c := range alias {
+		if !isAliasChar(c) {
+			return domain.ErrInvalidAlias
+		}
+	}
+
+	// an alias must start and end with a letter or a digit
+	if !isAlphanumeric(rune(alias[0])) || !isAlphanumeric(rune(alias[len(alias)-1])) {
+		return domain.ErrInvalidAlias
+	}
+
+	if _, reserved := reservedAliases[strings.ToLower(alias)]; reserved {
+		return domain.ErrReservedAlias
+	}
+
+	return nil
+}
+
+func isAliasChar(c rune) bool {
+	return isAlphanumeric(c) || c == '-' || c == '_' || c == '.'
+}
+
+func isAlphanumeric(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		name        string
+		alias       string
+		expectedErr error
+	}{
+		{name: "Simple Alias", alias: "spring-sale", expectedErr: nil},
+		{name: "Alias With Digits And Underscore", alias: "sale_2026", expectedErr: nil},
+		{name: "Alias With Dot", alias: "v1.2", expectedErr: nil},
+		{name: "Too Short", alias: "ab", expectedErr: domain.ErrInvalidAlias},
+		{name: "Too Long", alias: strings.Repeat("a", aliasMaxLength+1), expectedErr: domain.ErrInvalidAlias},
+		{name: "Slash Is Not Allowed", alias: "spring/sale", expectedErr: domain.ErrInvalidAlias},
+		{name: "Space Is Not Allowed", alias: "spring sale", expectedErr: domain.ErrInvalidAlias},
+		{name: "Non ASCII Is Not Allowed", alias: "ventă", expectedErr: domain.ErrInvalidAlias},
+		{name: "Leading Dash", alias: "-sale", expectedErr: domain.ErrInvalidAlias},
+		{name: "Trailing Dot", alias: "sale.", expectedErr: domain.ErrInvalidAlias},
+		{name: "Reserved Word", alias: "health", expectedErr: domain.ErrReservedAlias},
+		{name: "Reserved Word Different Case", alias: "Health", expectedErr: domain.ErrReservedAlias},
+		{name: "Reserved File Name", alias: "openapi-spec.yaml", expectedErr: domain.ErrReservedAlias},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateAlias(tt.alias); err != tt.expectedErr {
+				t.Errorf("validateAlias(%q) = %v; want %v", tt.alias, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func (u *shortLinkUsecase) Disable(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	return u.setDisabled(ctx, link, true)
+}
+
+func (u *shortLinkUsecase) Enable(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	return u.setDisabled(ctx, link, false)
+}
+
+// setDisabled changes the flag of the link, setting it to its current value is not recorded in the history.
+func (u *shortLinkUsecase) setDisabled(ctx context.Context, link domain.LinkRef, disabled bool) (domain.ShortLink, error) {
+	link, err := u.resolveLink(link)
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	before, err := u.shortLinkRepo.SetDisabled(ctx, link, disabled)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.ShortLink{}, domain.ErrShortLinkNotFound
+		}
+
+		return domain.ShortLink{}, fmt.Errorf("Usecase.setDisabled (link: %s): %w", link, err)
+	}
+
+	after := before
+	after.Disabled = disabled
+	if before.Disabled != disabled {
+		action := domain.RevisionActionEnable
+		if disabled {
+			action = domain.RevisionActionDisable
+		}
+		u.recordRevision(ctx, action, before, after)
+	}
+
+	return after, nil
+}
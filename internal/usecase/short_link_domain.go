@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// Domains are the short domains served by the deployment, each one has its own namespace of keys.
+type Domains struct {
+	// Domain of the links stored without a domain, including the links created before the domains were added
+	Primary string
+	Others  []string
+}
+
+// normalizeDomain lowercases the host and removes the trailing dot of a fully qualified name, the port is kept.
+func normalizeDomain(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// resolve returns the stored form of the requested domain, which is empty for the primary domain.
+func (d Domains) resolve(requested string) (string, error) {
+	requested = normalizeDomain(requested)
+	if requested == "" || requested == d.Primary {
+		return "", nil
+	}
+	if slices.Contains(d.Others, requested) {
+		return requested, nil
+	}
+
+	return "", domain.ErrUnknownDomain
+}
+
+// resolveHost returns the stored form of the domain a link is followed on.
+// The unknown hosts, like the internal names of the service, are served as the primary domain.
+func (d Domains) resolveHost(host string) string {
+	resolved, err := d.resolve(host)
+	if err != nil {
+		return ""
+	}
+
+	return resolved
+}
+
+// host returns the host of a stored domain.
+func (d Domains) host(stored string) string {
+	if stored == "" {
+		return d.Primary
+	}
+
+	return stored
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestDomainsResolve(t *testing.T) {
+	domains := Domains{Primary: "sho.rt", Others: []string{"go.brand.com", "localhost:8080"}}
+
+	tests := []struct {
+		requested   string
+		expected    string
+		expectedErr error
+	}{
+		{requested: "", expected: ""},
+		{requested: "sho.rt", expected: ""},
+		{requested: "SHO.RT.", expected: ""},
+		{requested: "go.brand.com", expected: "go.brand.com"},
+		{requested: "Go.Brand.com", expected: "go.brand.com"},
+		{requested: "localhost:8080", expected: "localhost:8080"},
+		{requested: "localhost", expectedErr: domain.ErrUnknownDomain},
+		{requested: "brand.com", expectedErr: domain.ErrUnknownDomain},
+	}
+
+	for _, tt := range tests {
+		got, err := domains.resolve(tt.requested)
+		if err != tt.expectedErr {
+			t.Errorf("resolve(%q) error = %v; want %v", tt.requested, err, tt.expectedErr)
+		}
+		if got != tt.expected {
+			t.Errorf("resolve(%q) = %q; want %q", tt.requested, got, tt.expected)
+		}
+	}
+}
+
+func TestDomainsResolveHost(t *testing.T) {
+	domains := Domains{Primary: "sho.rt", Others: []string{"go.brand.com"}}
+
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{host: "sho.rt", expected: ""},
+		{host: "go.brand.com", expected: "go.brand.com"},
+		{host: "shortener-service:8080", expected: ""},
+	}
+
+	for _, tt := range tests {
+		if got := domains.resolveHost(tt.host); got != tt.expected {
+			t.Errorf("resolveHost(%q) = %q; want %q", tt.host, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// maxExpiration is the longest lifetime of a link, set by an absolute expiry time or a TTL.
+const maxExpiration = 100 * 365 * 24 * time.Hour
+
+// validateExpiration accepts either an absolute expiry time or a relative TTL, the zero values mean no expiry.
+func validateExpiration(expiresAt time.Time, ttl time.Duration) error {
+	if ttl < 0 || ttl > maxExpiration || (ttl > 0 && !expiresAt.IsZero()) {
+		return domain.ErrInvalidExpiration
+	}
+
+	return validateExpiresAt(expiresAt)
+}
+
+// validateExpiresAt rejects the expiry times in the past or beyond maxExpiration, the zero time means no expiry.
+func validateExpiresAt(expiresAt time.Time) error {
+	if expiresAt.IsZero() {
+		return nil
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) || expiresAt.After(now.Add(maxExpiration)) {
+		return domain.ErrInvalidExpiration
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestValidateExpiration(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		expiresAt   time.Time
+		ttl         time.Duration
+		expectedErr error
+	}{
+		{name: "No Expiry", expectedErr: nil},
+		{name: "Future Time", expiresAt: now.Add(24 * time.Hour), expectedErr: nil},
+		{name: "Past Time", expiresAt: now.Add(-time.Minute), expectedErr: domain.ErrInvalidExpiration},
+		{name: "Too Far Future Time", expiresAt: now.Add(maxExpiration + time.Hour), expectedErr: domain.ErrInvalidExpiration},
+		{name: "Relative Duration", ttl: 72 * time.Hour, expectedErr: nil},
+		{name: "Negative Duration", ttl: -time.Hour, expectedErr: domain.ErrInvalidExpiration},
+		{name: "Too Long Duration", ttl: maxExpiration + time.Hour, expectedErr: domain.ErrInvalidExpiration},
+		{name: "Time And Duration", expiresAt: now.Add(time.Hour), ttl: time.Hour, expectedErr: domain.ErrInvalidExpiration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateExpiration(tt.expiresAt, tt.ttl); err != tt.expectedErr {
+				t.Errorf("validateExpiration(%v, %v) = %v; want %v", tt.expiresAt, tt.ttl, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func validateQueryForwarding(policy domain.QueryForwarding) error {
+	switch policy {
+	case domain.QueryForwardingNone, domain.QueryForwardingKeep, domain.QueryForwardingOverride, domain.QueryForwardingAppend:
+		return nil
+	}
+
+	return domain.ErrInvalidQueryForwarding
+}
+
+// forwardRequest returns the destination of the target extended with the path suffix and query of the followed link,
+// according to the forwarding options of the link. The destination is returned as is when nothing is forwarded,
+// so its original encoding is kept.
+func forwardRequest(target domain.RedirectTarget, pathSuffix string, rawQuery string) (string, error) {
+	if !target.ForwardPath {
+		pathSuffix = ""
+	}
+	if target.QueryForwarding == domain.QueryForwardingNone {
+		rawQuery = ""
+	}
+
+	suffix := cleanPathSuffix(pathSuffix)
+	incoming := splitQuery(rawQuery)
+	if suffix == "" && len(incoming) == 0 {
+		return target.OriginalURL, nil
+	}
+
+	destination, err := url.Parse(target.OriginalURL)
+	if err != nil {
+		return "", fmt.Errorf("parse destination: %w", err)
+	}
+
+	if suffix != "" {
+		// the escaped forms are joined, so the encoded characters of the destination path are kept
+		escapedPath := strings.TrimSuffix(destination.EscapedPath(), "/") + (&url.URL{Path: suffix}).EscapedPath()
+		unescapedPath, err := url.PathUnescape(escapedPath)
+		if err != nil {
+			return "", fmt.Errorf("unescape path: %w", err)
+		}
+		destination.Path = unescapedPath
+		destination.RawPath = escapedPath
+	}
+
+	if len(incoming) > 0 {
+		destination.RawQuery = mergeQuery(splitQuery(destination.RawQuery), incoming, target.QueryForwarding)
+	}
+
+	return destination.String(), nil
+}
+
+// cleanPathSuffix removes the dot segments of the suffix, so it cannot climb above the destination path.
+// The trailing slash is kept, an empty result means there is nothing to forward.
+func cleanPathSuffix(suffix string) string {
+	cleaned := path.Clean("/" + suffix)
+	if cleaned == "/" {
+		return ""
+	}
+	if strings.HasSuffix(suffix, "/") {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
+
+// queryParam is a parameter of a raw query, kept encoded so the merged query is not re-encoded.
+type queryParam struct {
+	key string
+	raw string
+}
+
+func splitQuery(rawQuery string) []queryParam {
+	var params []queryParam
+	for _, raw := range strings.Split(rawQuery, "&") {
+		if raw == "" {
+			continue
+		}
+
+		key, _, _ := strings.Cut(raw, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		params = append(params, queryParam{key: key, raw: raw})
+	}
+
+	return params
+}
+
+// mergeQuery appends the incoming parameters to the destination ones, the policy resolves the keys present in both.
+// The order of the parameters is kept.
+func mergeQuery(destination []queryParam, incoming []queryParam, policy domain.QueryForwarding) string {
+	switch policy {
+	case domain.QueryForwardingKeep:
+		incoming = withoutKeys(incoming, destination)
+	case domain.QueryForwardingOverride:
+		destination = withoutKeys(destination, incoming)
+	}
+
+	parts := make([]string, 0, len(destination)+len(incoming))
+	for _, param := range destination {
+		parts = append(parts, param.raw)
+	}
+	for _, param := range incoming {
+		parts = append(parts, param.raw)
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// withoutKeys returns the params whose key is not used by any of the other params.
+func withoutKeys(params []queryParam, others []queryParam) []queryParam {
+	keys := make(map[string]struct{}, len(others))
+	for _, other := range others {
+		keys[other.key] = struct{}{}
+	}
+
+	filtered := make([]queryParam, 0, len(params))
+	for _, param := range params {
+		if _, ok := keys[param.key]; !ok {
+			filtered = append(filtered, param)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestForwardRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		policy      domain.QueryForwarding
+		forwardPath bool
+		pathSuffix  string
+		rawQuery    string
+		expected    string
+	}{
+		{name: "Nothing Forwarded", destination: "https://example.com/landing?a=1", policy: domain.QueryForwardingNone, rawQuery: "utm_source=mail", expected: "https://example.com/landing?a=1"},
+		{name: "Empty Query", destination: "https://example.com/landing?a=1", policy: domain.QueryForwardingKeep, expected: "https://example.com/landing?a=1"},
+		{name: "Destination Without Query", destination: "https://example.com/landing", policy: domain.QueryForwardingKeep, rawQuery: "utm_source=mail", expected: "https://example.com/landing?utm_source=mail"},
+		{name: "Destination Without Path", destination: "https://example.com", policy: domain.QueryForwardingKeep, rawQuery: "utm_source=mail", expected: "https://example.com?utm_source=mail"},
+		{name: "Keep Destination Value", destination: "https://example.com/?utm_source=site&a=1", policy: domain.QueryForwardingKeep, rawQuery: "utm_source=mail&utm_medium=email", expected: "https://example.com/?utm_source=site&a=1&utm_medium=email"},
+		{name: "Override Destination Value", destination: "https://example.com/?utm_source=site&a=1", policy: domain.QueryForwardingOverride, rawQuery: "utm_source=mail&utm_medium=email", expected: "https://example.com/?a=1&utm_source=mail&utm_medium=email"},
+		{name: "Override Repeated Key", destination: "https://example.com/?tag=a&tag=b", policy: domain.QueryForwardingOverride, rawQuery: "tag=c", expected: "https://example.com/?tag=c"},
+		{name: "Append Both Values", destination: "https://example.com/?tag=a", policy: domain.QueryForwardingAppend, rawQuery: "tag=b", expected: "https://example.com/?tag=a&tag=b"},
+		{name: "Encoded Keys Are Compared Decoded", destination: "https://example.com/?a%20b=1", policy: domain.QueryForwardingKeep, rawQuery: "a+b=2", expected: "https://example.com/?a%20b=1"},
+		{name: "Encoding Is Kept", destination: "https://example.com/?q=caf%C3%A9", policy: domain.QueryForwardingAppend, rawQuery: "next=%2Fhome%3Fx%3D1&empty=&flag", expected: "https://example.com/?q=caf%C3%A9&next=%2Fhome%3Fx%3D1&empty=&flag"},
+		{name: "Empty Parameters Are Skipped", destination: "https://example.com/", policy: domain.QueryForwardingKeep, rawQuery: "&a=1&&b=2&", expected: "https://example.com/?a=1&b=2"},
+		{name: "Fragment Stays Last", destination: "https://example.com/page?a=1#section", policy: domain.QueryForwardingKeep, rawQuery: "b=2", expected: "https://example.com/page?a=1&b=2#section"},
+		{name: "Path Not Forwarded", destination: "https://example.com/docs", policy: domain.QueryForwardingNone, pathSuffix: "/guide", expected: "https://example.com/docs"},
+		{name: "Path Appended", destination: "https://example.com/docs", forwardPath: true, pathSuffix: "/guide/install", expected: "https://example.com/docs/guide/install"},
+		{name: "Path Without Double Slash", destination: "https://example.com/docs/", forwardPath: true, pathSuffix: "/guide", expected: "https://example.com/docs/guide"},
+		{name: "Path On Root", destination: "https://example.com", forwardPath: true, pathSuffix: "/guide", expected: "https://example.com/guide"},
+		{name: "Path Trailing Slash Kept", destination: "https://example.com/docs", forwardPath: true, pathSuffix: "/guide/", expected: "https://example.com/docs/guide/"},
+		{name: "Path Dot Segments Removed", destination: "https://example.com/docs", forwardPath: true, pathSuffix: "/a/../../../admin", expected: "https://example.com/docs/admin"},
+		{name: "Path Of Only Dot Segments", destination: "https://example.com/docs", forwardPath: true, pathSuffix: "/..", expected: "https://example.com/docs"},
+		{name: "Path Is Escaped", destination: "https://example.com/docs", forwardPath: true, pathSuffix: "/a b/ü", expected: "https://example.com/docs/a%20b/%C3%BC"},
+		{name: "Destination Path Encoding Kept", destination: "https://example.com/a%2Fb", forwardPath: true, pathSuffix: "/c", expected: "https://example.com/a%2Fb/c"},
+		{name: "Path Before Query And Fragment", destination: "https://example.com/docs?a=1#top", policy: domain.QueryForwardingOverride, forwardPath: true, pathSuffix: "/guide", rawQuery: "a=2", expected: "https://example.com/docs/guide?a=2#top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := domain.RedirectTarget{
+				OriginalURL:     tt.destination,
+				QueryForwarding: tt.policy,
+				ForwardPath:     tt.forwardPath,
+			}
+
+			got, err := forwardRequest(target, tt.pathSuffix, tt.rawQuery)
+			if err != nil {
+				t.Fatalf("forwardRequest() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("forwardRequest() = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateQueryForwarding(t *testing.T) {
+	tests := []struct {
+		policy      domain.QueryForwarding
+		expectedErr error
+	}{
+		{policy: domain.QueryForwardingNone, expectedErr: nil},
+		{policy: domain.QueryForwardingKeep, expectedErr: nil},
+		{policy: domain.QueryForwardingOverride, expectedErr: nil},
+		{policy: domain.QueryForwardingAppend, expectedErr: nil},
+		{policy: "merge", expectedErr: domain.ErrInvalidQueryForwarding},
+		{policy: "KEEP", expectedErr: domain.ErrInvalidQueryForwarding},
+	}
+
+	for _, tt := range tests {
+		if err := validateQueryForwarding(tt.policy); err != tt.expectedErr {
+			t.Errorf("validateQueryForwarding(%q) = %v; want %v", tt.policy, err, tt.expectedErr)
+		}
+	}
+}
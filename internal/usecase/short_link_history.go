@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// redactedValue replaces the password in the history, only its presence and its changes are recorded.
+const redactedValue = "[redacted]"
+
+func (u *shortLinkUsecase) History(ctx context.Context, query domain.HistoryQuery) (domain.HistoryResult, error) {
+	if query.Limit < 0 || query.Limit > maxListLimit {
+		return domain.HistoryResult{}, domain.ErrInvalidListQuery
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultListLimit
+	}
+
+	link, err := u.resolveLink(domain.LinkRef{Domain: query.LinkDomain, Key: query.LinkKey})
+	if err != nil {
+		return domain.HistoryResult{}, err
+	}
+	query.LinkDomain = link.Domain
+
+	// the link itself is not looked up, the history outlives the deletion of the link
+	result, err := u.linkRevisionRepo.FindMany(ctx, query)
+	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			return domain.HistoryResult{}, domain.ErrInvalidCursor
+		}
+
+		return domain.HistoryResult{}, fmt.Errorf("Usecase.History (link: %s): %w", link, err)
+	}
+
+	return result, nil
+}
+
+// recordRevision stores the change of the link made by the caller of the context. The change is already applied,
+// so a failure is only logged, and an update which changes nothing is not recorded.
+func (u *shortLinkUsecase) recordRevision(ctx context.Context, action domain.RevisionAction, before domain.ShortLink, after domain.ShortLink) {
+	changes := diffShortLinks(before, after)
+	if len(changes) == 0 && action == domain.RevisionActionUpdate {
+		return
+	}
+
+	revision := domain.NewLinkRevision(after, action, domain.ActorFromContext(ctx), changes)
+	u.insertRevisions(ctx, []domain.LinkRevision{revision})
+}
+
+// recordCreations stores the revisions of the created links in one call.
+func (u *shortLinkUsecase) recordCreations(ctx context.Context, ents []domain.ShortLink) {
+	actor := domain.ActorFromContext(ctx)
+	revisions := make([]domain.LinkRevision, 0, len(ents))
+	for _, ent := range ents {
+		revisions = append(revisions, domain.NewLinkRevision(ent, domain.RevisionActionCreate, actor, diffShortLinks(domain.ShortLink{}, ent)))
+	}
+
+	u.insertRevisions(ctx, revisions)
+}
+
+func (u *shortLinkUsecase) insertRevisions(ctx context.Context, revisions []domain.LinkRevision) {
+	if len(revisions) == 0 {
+		return
+	}
+
+	// the revisions are stored even when the caller goes away right after the change
+	if err := u.linkRevisionRepo.InsertMany(context.WithoutCancel(ctx), revisions); err != nil {
+		u.logger.Error("unable to record link revisions",
+			slog.Int("count", len(revisions)),
+			slog.String("action", string(revisions[0].Action)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// revisionField is an audited field of a link, compared by its raw value, recorded by its formatted value.
+type revisionField struct {
+	name  string
+	raw   string
+	value string
+}
+
+// diffShortLinks returns the audited fields which differ between the two versions of a link.
+func diffShortLinks(before domain.ShortLink, after domain.ShortLink) []domain.FieldChange {
+	beforeFields, afterFields := revisionFields(before), revisionFields(after)
+
+	var changes []domain.FieldChange
+	for i, field := range afterFields {
+		if field.raw != beforeFields[i].raw {
+			changes = append(changes, domain.FieldChange{Field: field.name, Old: beforeFields[i].value, New: field.value})
+		}
+	}
+
+	return changes
+}
+
+// revisionFields returns the audited fields of the link in a stable order, named like in the HTTP API.
+// The values of the unset fields are empty.
+func revisionFields(link domain.ShortLink) []revisionField {
+	password := ""
+	if link.IsProtected() {
+		password = redactedValue
+	}
+
+	return []revisionField{
+		newRevisionField("url", link.OriginalURL),
+		newRevisionField("expires_at", formatRevisionTime(link.ExpiresAt)),
+		newRevisionField("max_hits", formatRevisionInt(int(link.MaxHits))),
+		// a new password has a new hash, so setting it again is recorded too
+		{name: "password", raw: link.PasswordHash, value: password},
+		newRevisionField("redirect_status", formatRevisionInt(link.RedirectStatus)),
+		newRevisionField("query_forwarding", string(link.QueryForwarding)),
+		newRevisionField("forward_path", formatRevisionBool(link.ForwardPath)),
+		newRevisionField("deleted_at", formatRevisionTime(link.DeletedAt)),
+		newRevisionField("deleted_by", link.DeletedBy),
+		newRevisionField("disabled", formatRevisionBool(link.Disabled)),
+	}
+}
+
+// newRevisionField returns a field recorded as it is compared.
+func newRevisionField(name string, value string) revisionField {
+	return revisionField{name: name, raw: value, value: value}
+}
+
+func formatRevisionTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatRevisionBool(b bool) string {
+	if !b {
+		return ""
+	}
+
+	return "true"
+}
+
+func formatRevisionInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestDiffShortLinks(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("EET", 2*60*60))
+	deletedAt := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	link := domain.ShortLink{Key: "abc", OriginalURL: "https://example.com", MaxHits: 10}
+
+	tests := []struct {
+		name     string
+		before   domain.ShortLink
+		after    domain.ShortLink
+		expected []domain.FieldChange
+	}{
+		{name: "No Changes", before: link, after: link, expected: nil},
+		{
+			name:   "Created",
+			before: domain.ShortLink{},
+			after:  domain.ShortLink{Key: "abc", OriginalURL: "https://example.com", ExpiresAt: expiresAt, PasswordHash: "hash", ForwardPath: true},
+			expected: []domain.FieldChange{
+				{Field: "url", New: "https://example.com"},
+				{Field: "expires_at", New: "2030-01-02T01:04:05Z"},
+				{Field: "password", New: redactedValue},
+				{Field: "forward_path", New: "true"},
+			},
+		},
+		{
+			name:   "Updated",
+			before: link,
+			after:  domain.ShortLink{Key: "abc", OriginalURL: "https://example.org", RedirectStatus: 301, QueryForwarding: domain.QueryForwardingKeep},
+			expected: []domain.FieldChange{
+				{Field: "url", Old: "https://example.com", New: "https://example.org"},
+				{Field: "max_hits", Old: "10"},
+				{Field: "redirect_status", New: "301"},
+				{Field: "query_forwarding", New: "keep"},
+			},
+		},
+		{
+			name:     "Password Replaced",
+			before:   domain.ShortLink{PasswordHash: "old"},
+			after:    domain.ShortLink{PasswordHash: "new"},
+			expected: []domain.FieldChange{{Field: "password", Old: redactedValue, New: redactedValue}},
+		},
+		{
+			name:     "Disabled",
+			before:   link,
+			after:    domain.ShortLink{Key: "abc", OriginalURL: "https://example.com", MaxHits: 10, Disabled: true},
+			expected: []domain.FieldChange{{Field: "disabled", New: "true"}},
+		},
+		{
+			name:   "Deleted",
+			before: link,
+			after:  domain.ShortLink{Key: "abc", OriginalURL: "https://example.com", MaxHits: 10, DeletedAt: deletedAt, DeletedBy: "key-1"},
+			expected: []domain.FieldChange{
+				{Field: "deleted_at", New: "2026-05-06T07:08:09Z"},
+				{Field: "deleted_by", New: "key-1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffShortLinks(tt.before, tt.after)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("diffShortLinks() = %+v; want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	minLinkPasswordLength = 4
+	// bcrypt ignores the bytes after the 72nd
+	maxLinkPasswordLength = 72
+	// a client is blocked on a link after this many failed passwords within the window
+	maxPasswordFailures   = 5
+	passwordFailureWindow = 15 * time.Minute
+)
+
+// hashLinkPassword validates the length of a new link password and returns its bcrypt hash.
+func hashLinkPassword(password string) (string, error) {
+	if len(password) < minLinkPasswordLength || len(password) > maxLinkPasswordLength {
+		return "", domain.ErrInvalidLinkPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash link password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// checkPassword verifies the attempt against the password hash of a link, a link without hash is public.
+// The failed attempts are counted per client, so guessing is throttled.
+func (u *shortLinkUsecase) checkPassword(ctx context.Context, link domain.LinkRef, passwordHash string, attempt domain.PasswordAttempt) error {
+	if passwordHash == "" {
+		return nil
+	}
+	if attempt.Password == "" {
+		return domain.ErrPasswordRequired
+	}
+
+	failures, err := u.passwordAttemptRepo.Failures(ctx, link, attempt.ClientIP)
+	if err != nil {
+		u.logger.Warn("failed to read the password failures, continue", slog.Any("err", err))
+	}
+	if failures >= maxPasswordFailures {
+		return domain.ErrTooManyPasswordAttempts
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(attempt.Password))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		if err := u.passwordAttemptRepo.AddFailure(ctx, link, attempt.ClientIP, passwordFailureWindow); err != nil {
+			u.logger.Warn("failed to count the password failure, continue", slog.Any("err", err))
+		}
+
+		return domain.ErrWrongPassword
+	}
+	if err != nil {
+		return fmt.Errorf("compare link password: %w", err)
+	}
+
+	return nil
+}
+
+func isPasswordError(err error) bool {
+	return err == domain.ErrPasswordRequired || err == domain.ErrWrongPassword || err == domain.ErrTooManyPasswordAttempts
+}
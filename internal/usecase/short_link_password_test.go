@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// memoryPasswordAttemptRepo counts the failures without expiring them.
+type memoryPasswordAttemptRepo struct {
+	failures map[string]int
+}
+
+func (r *memoryPasswordAttemptRepo) Failures(_ context.Context, link domain.LinkRef, clientIP string) (int, error) {
+	return r.failures[link.String()+" "+clientIP], nil
+}
+
+func (r *memoryPasswordAttemptRepo) AddFailure(_ context.Context, link domain.LinkRef, clientIP string, _ time.Duration) error {
+	r.failures[link.String()+" "+clientIP]++
+	return nil
+}
+
+func TestHashLinkPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		expectedErr error
+	}{
+		{name: "Valid", password: "s3cret", expectedErr: nil},
+		{name: "Too Short", password: "abc", expectedErr: domain.ErrInvalidLinkPassword},
+		{name: "Too Long", password: strings.Repeat("a", maxLinkPasswordLength+1), expectedErr: domain.ErrInvalidLinkPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := hashLinkPassword(tt.password); err != tt.expectedErr {
+				t.Errorf("hashLinkPassword() error = %v; want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := hashLinkPassword("s3cret")
+	if err != nil {
+		t.Fatalf("hashLinkPassword() error = %v", err)
+	}
+
+	repo := &memoryPasswordAttemptRepo{failures: map[string]int{}}
+	u := &shortLinkUsecase{
+		logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		passwordAttemptRepo: repo,
+	}
+	ctx := context.Background()
+	link := domain.LinkRef{Key: "abc123"}
+
+	if err := u.checkPassword(ctx, link, "", domain.PasswordAttempt{}); err != nil {
+		t.Errorf("public link: error = %v; want nil", err)
+	}
+	if err := u.checkPassword(ctx, link, hash, domain.PasswordAttempt{ClientIP: "192.0.2.1"}); err != domain.ErrPasswordRequired {
+		t.Errorf("missing password: error = %v; want %v", err, domain.ErrPasswordRequired)
+	}
+	if err := u.checkPassword(ctx, link, hash, domain.PasswordAttempt{Password: "s3cret", ClientIP: "192.0.2.1"}); err != nil {
+		t.Errorf("right password: error = %v; want nil", err)
+	}
+
+	wrong := domain.PasswordAttempt{Password: "guess", ClientIP: "192.0.2.1"}
+	for i := range maxPasswordFailures {
+		if err := u.checkPassword(ctx, link, hash, wrong); err != domain.ErrWrongPassword {
+			t.Fatalf("wrong password %d: error = %v; want %v", i, err, domain.ErrWrongPassword)
+		}
+	}
+
+	// the right password is rejected as well once the client is blocked
+	blocked := domain.PasswordAttempt{Password: "s3cret", ClientIP: "192.0.2.1"}
+	if err := u.checkPassword(ctx, link, hash, blocked); err != domain.ErrTooManyPasswordAttempts {
+		t.Errorf("blocked client: error = %v; want %v", err, domain.ErrTooManyPasswordAttempts)
+	}
+
+	other := domain.PasswordAttempt{Password: "s3cret", ClientIP: "192.0.2.2"}
+	if err := u.checkPassword(ctx, link, hash, other); err != nil {
+		t.Errorf("other client: error = %v; want nil", err)
+	}
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/qrimage"
+)
+
+const (
+	defaultQRCodeSize   = 256
+	minQRCodeSize       = 64
+	maxQRCodeSize       = 2048
+	defaultQRCodeMargin = 4
+	maxQRCodeMargin     = 16
+)
+
+func (u *shortLinkUsecase) QRCode(ctx context.Context, query domain.QRCodeQuery) (domain.QRCode, error) {
+	options, err := newQRCodeOptions(query)
+	if err != nil {
+		return domain.QRCode{}, err
+	}
+	if options.Logo && !u.qrRenderer.HasLogo() {
+		return domain.QRCode{}, domain.ErrQRCodeLogoNotConfigured
+	}
+
+	link, err := u.resolveLink(domain.LinkRef{Domain: query.Domain, Key: query.Key})
+	if err != nil {
+		return domain.QRCode{}, err
+	}
+
+	ent, err := u.shortLinkRepo.FindOne(ctx, link)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.QRCode{}, domain.ErrShortLinkNotFound
+		}
+
+		return domain.QRCode{}, fmt.Errorf("Usecase.QRCode (link: %s): %w", link, err)
+	}
+	if ent.IsExpired(time.Now()) {
+		return domain.QRCode{}, domain.ErrShortLinkExpired
+	}
+
+	shortURL := u.buildShortURL(u.domains.host(ent.Domain), ent.Key)
+
+	qrCode := domain.QRCode{ContentType: "image/png"}
+	if query.Format == domain.QRCodeFormatSVG {
+		qrCode.ContentType = "image/svg+xml"
+		qrCode.Data, err = u.qrRenderer.SVG(shortURL, options)
+	} else {
+		qrCode.Data, err = u.qrRenderer.PNG(shortURL, options)
+	}
+	if err != nil {
+		if errors.Is(err, qrimage.ErrTooSmall) {
+			return domain.QRCode{}, domain.ErrInvalidQRCodeQuery
+		}
+
+		return domain.QRCode{}, fmt.Errorf("Usecase.QRCode (link: %s): render: %w", link, err)
+	}
+
+	return qrCode, nil
+}
+
+// newQRCodeOptions validates the query and fills in the defaults. A logo hides modules in the middle of the code,
+// so it requires one of the two highest error correction levels, which is also the default with a logo.
+func newQRCodeOptions(query domain.QRCodeQuery) (qrimage.Options, error) {
+	switch query.Format {
+	case "", domain.QRCodeFormatPNG, domain.QRCodeFormatSVG:
+	default:
+		return qrimage.Options{}, domain.ErrInvalidQRCodeQuery
+	}
+
+	options := qrimage.Options{
+		Size:   query.Size,
+		Margin: defaultQRCodeMargin,
+		Level:  qrimage.LevelMedium,
+		Logo:   query.Logo,
+	}
+
+	if options.Size == 0 {
+		options.Size = defaultQRCodeSize
+	}
+	if options.Size < minQRCodeSize || options.Size > maxQRCodeSize {
+		return qrimage.Options{}, domain.ErrInvalidQRCodeQuery
+	}
+
+	if query.Margin != nil {
+		if *query.Margin < 0 || *query.Margin > maxQRCodeMargin {
+			return qrimage.Options{}, domain.ErrInvalidQRCodeQuery
+		}
+		options.Margin = *query.Margin
+	}
+
+	switch strings.ToUpper(query.Level) {
+	case "":
+		if query.Logo {
+			options.Level = qrimage.LevelHigh
+		}
+	case "L":
+		options.Level = qrimage.LevelLow
+	case "M":
+		options.Level = qrimage.LevelMedium
+	case "Q":
+		options.Level = qrimage.LevelQuartile
+	case "H":
+		options.Level = qrimage.LevelHigh
+	default:
+		return qrimage.Options{}, domain.ErrInvalidQRCodeQuery
+	}
+	if query.Logo && options.Level < qrimage.LevelQuartile {
+		return qrimage.Options{}, domain.ErrInvalidQRCodeQuery
+	}
+
+	var err error
+	options.Foreground, err = parseHexColor(query.Foreground, color.RGBA{A: 0xff})
+	if err != nil {
+		return qrimage.Options{}, err
+	}
+	options.Background, err = parseHexColor(query.Background, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+	if err != nil {
+		return qrimage.Options{}, err
+	}
+	// a code drawn in a single color cannot be scanned
+	if options.Foreground == options.Background {
+		return qrimage.Options{}, domain.ErrInvalidQRCodeQuery
+	}
+
+	return options, nil
+}
+
+// parseHexColor parses an opaque color like "#1a2b3c", the leading "#" is optional.
+func parseHexColor(value string, fallback color.RGBA) (color.RGBA, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "#"))
+	if err != nil || len(decoded) != 3 {
+		return color.RGBA{}, domain.ErrInvalidQRCodeQuery
+	}
+
+	return color.RGBA{R: decoded[0], G: decoded[1], B: decoded[2], A: 0xff}, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/qrimage"
+)
+
+func TestNewQRCodeOptions(t *testing.T) {
+	black := color.RGBA{A: 0xff}
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	zero, twenty := 0, 20
+
+	tests := []struct {
+		name        string
+		query       domain.QRCodeQuery
+		expected    qrimage.Options
+		expectedErr error
+	}{
+		{name: "Defaults", query: domain.QRCodeQuery{}, expected: qrimage.Options{Size: 256, Margin: 4, Level: qrimage.LevelMedium, Foreground: black, Background: white}},
+		{name: "Custom", query: domain.QRCodeQuery{Format: domain.QRCodeFormatSVG, Size: 512, Margin: &zero, Level: "q", Foreground: "#1A2B3C", Background: "fafafa"}, expected: qrimage.Options{Size: 512, Margin: 0, Level: qrimage.LevelQuartile, Foreground: color.RGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0xff}, Background: color.RGBA{R: 0xfa, G: 0xfa, B: 0xfa, A: 0xff}}},
+		{name: "Logo Defaults To High Level", query: domain.QRCodeQuery{Logo: true}, expected: qrimage.Options{Size: 256, Margin: 4, Level: qrimage.LevelHigh, Foreground: black, Background: white, Logo: true}},
+		{name: "Logo With Quartile Level", query: domain.QRCodeQuery{Logo: true, Level: "Q"}, expected: qrimage.Options{Size: 256, Margin: 4, Level: qrimage.LevelQuartile, Foreground: black, Background: white, Logo: true}},
+		{name: "Logo With Low Level", query: domain.QRCodeQuery{Logo: true, Level: "L"}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Unknown Format", query: domain.QRCodeQuery{Format: "gif"}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Too Small", query: domain.QRCodeQuery{Size: 32}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Too Large", query: domain.QRCodeQuery{Size: 4096}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Margin Too Large", query: domain.QRCodeQuery{Margin: &twenty}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Unknown Level", query: domain.QRCodeQuery{Level: "X"}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Invalid Color", query: domain.QRCodeQuery{Foreground: "#12345"}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Named Color", query: domain.QRCodeQuery{Background: "red"}, expectedErr: domain.ErrInvalidQRCodeQuery},
+		{name: "Same Colors", query: domain.QRCodeQuery{Foreground: "#ffffff"}, expectedErr: domain.ErrInvalidQRCodeQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newQRCodeOptions(tt.query)
+			if err != tt.expectedErr {
+				t.Fatalf("newQRCodeOptions() error = %v; want %v", err, tt.expectedErr)
+			}
+			if got != tt.expected {
+				t.Errorf("newQRCodeOptions() = %+v; want %+v", got, tt.expected)
+			}
+		})
+	}
+}
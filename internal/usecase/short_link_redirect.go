@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// RedirectOptions are the server-wide settings of the redirects.
+type RedirectOptions struct {
+	// Status code of the links without their own
+	DefaultStatus int
+	// Longest time a client may cache a permanent redirect, zero value disables the caching
+	PermanentMaxAge time.Duration
+}
+
+// validateRedirectStatus accepts the zero value, meaning the server default, and the redirect codes
+// which keep the link usable by both browsers and API clients.
+func validateRedirectStatus(status int) error {
+	switch status {
+	case 0, http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return nil
+	}
+
+	return domain.ErrInvalidRedirectStatus
+}
+
+// newRedirect applies the server default status to the target and decides how long the client may cache it.
+func newRedirect(target domain.RedirectTarget, options RedirectOptions, now time.Time) domain.Redirect {
+	redirect := domain.Redirect{
+		URL:        target.OriginalURL,
+		StatusCode: target.RedirectStatus,
+	}
+	if redirect.StatusCode == 0 {
+		redirect.StatusCode = options.DefaultStatus
+	}
+
+	// a cached redirect never reaches the server, so it is neither counted nor checked again.
+	// Only the permanent redirects are cached, as browsers would keep them forever otherwise,
+	// and never when each hit matters.
+	permanent := redirect.StatusCode == http.StatusMovedPermanently || redirect.StatusCode == http.StatusPermanentRedirect
+	if !permanent || target.MaxHits > 0 || target.PasswordHash != "" {
+		return redirect
+	}
+
+	maxAge := options.PermanentMaxAge
+	if !target.ExpiresAt.IsZero() {
+		maxAge = min(maxAge, target.ExpiresAt.Sub(now))
+	}
+	if maxAge >= time.Second {
+		redirect.MaxAge = maxAge.Truncate(time.Second)
+	}
+
+	return redirect
+}
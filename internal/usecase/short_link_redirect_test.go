@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestValidateRedirectStatus(t *testing.T) {
+	tests := []struct {
+		status      int
+		expectedErr error
+	}{
+		{status: 0, expectedErr: nil},
+		{status: 301, expectedErr: nil},
+		{status: 302, expectedErr: nil},
+		{status: 307, expectedErr: nil},
+		{status: 308, expectedErr: nil},
+		{status: 200, expectedErr: domain.ErrInvalidRedirectStatus},
+		{status: 303, expectedErr: domain.ErrInvalidRedirectStatus},
+		{status: 404, expectedErr: domain.ErrInvalidRedirectStatus},
+	}
+
+	for _, tt := range tests {
+		if err := validateRedirectStatus(tt.status); err != tt.expectedErr {
+			t.Errorf("validateRedirectStatus(%d) = %v; want %v", tt.status, err, tt.expectedErr)
+		}
+	}
+}
+
+func TestNewRedirect(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	options := RedirectOptions{DefaultStatus: 302, PermanentMaxAge: time.Hour}
+
+	tests := []struct {
+		name           string
+		target         domain.RedirectTarget
+		expectedStatus int
+		expectedMaxAge time.Duration
+	}{
+		{name: "Server Default", target: domain.RedirectTarget{}, expectedStatus: 302},
+		{name: "Temporary", target: domain.RedirectTarget{RedirectStatus: 307}, expectedStatus: 307},
+		{name: "Permanent", target: domain.RedirectTarget{RedirectStatus: 301}, expectedStatus: 301, expectedMaxAge: time.Hour},
+		{name: "Permanent Expiring Soon", target: domain.RedirectTarget{RedirectStatus: 308, ExpiresAt: now.Add(90*time.Second + time.Millisecond)}, expectedStatus: 308, expectedMaxAge: 90 * time.Second},
+		{name: "Permanent Expiring Now", target: domain.RedirectTarget{RedirectStatus: 308, ExpiresAt: now.Add(time.Millisecond)}, expectedStatus: 308},
+		{name: "Permanent With Hit Limit", target: domain.RedirectTarget{RedirectStatus: 301, MaxHits: 10}, expectedStatus: 301},
+		{name: "Permanent With Password", target: domain.RedirectTarget{RedirectStatus: 301, PasswordHash: "hash"}, expectedStatus: 301},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.target.OriginalURL = "https://example.com"
+
+			got := newRedirect(tt.target, options, now)
+			if got.URL != "https://example.com" {
+				t.Errorf("newRedirect() URL = %q; want %q", got.URL, "https://example.com")
+			}
+			if got.StatusCode != tt.expectedStatus {
+				t.Errorf("newRedirect() status = %d; want %d", got.StatusCode, tt.expectedStatus)
+			}
+			if got.MaxAge != tt.expectedMaxAge {
+				t.Errorf("newRedirect() max age = %s; want %s", got.MaxAge, tt.expectedMaxAge)
+			}
+		})
+	}
+}
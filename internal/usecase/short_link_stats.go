@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+const (
+	defaultStatsTopLimit = 10
+	maxStatsTopLimit     = 100
+	// upper bound of timeline points in a single response
+	maxStatsBuckets = 1000
+)
+
+// defaultStatsRanges is the analytics range used when the query has no From time.
+var defaultStatsRanges = map[domain.StatsBucket]time.Duration{
+	domain.StatsBucketHour: 48 * time.Hour,
+	domain.StatsBucketDay:  30 * 24 * time.Hour,
+	domain.StatsBucketWeek: 12 * 7 * 24 * time.Hour,
+}
+
+var statsBucketSizes = map[domain.StatsBucket]time.Duration{
+	domain.StatsBucketHour: time.Hour,
+	domain.StatsBucketDay:  24 * time.Hour,
+	domain.StatsBucketWeek: 7 * 24 * time.Hour,
+}
+
+// newClickAnalyticsQuery applies the defaults to the stats query and validates it.
+func newClickAnalyticsQuery(query domain.StatsQuery, now time.Time) (domain.ClickAnalyticsQuery, error) {
+	bucketSize, ok := statsBucketSizes[query.Bucket]
+	if !ok {
+		return domain.ClickAnalyticsQuery{}, domain.ErrInvalidStatsQuery
+	}
+
+	to := query.To
+	if to.IsZero() {
+		to = now
+	}
+	from := query.From
+	if from.IsZero() {
+		from = to.Add(-defaultStatsRanges[query.Bucket])
+	}
+	if !from.Before(to) || to.Sub(from)/bucketSize > maxStatsBuckets {
+		return domain.ClickAnalyticsQuery{}, domain.ErrInvalidStatsQuery
+	}
+
+	topLimit := query.TopLimit
+	if topLimit < 0 || topLimit > maxStatsTopLimit {
+		return domain.ClickAnalyticsQuery{}, domain.ErrInvalidStatsQuery
+	}
+	if topLimit == 0 {
+		topLimit = defaultStatsTopLimit
+	}
+
+	return domain.ClickAnalyticsQuery{
+		LinkKey:    query.Key,
+		LinkDomain: query.Domain,
+		Bucket:     query.Bucket,
+		From:       from.UTC(),
+		To:         to.UTC(),
+		TopLimit:   topLimit,
+	}, nil
+}
+
+// truncateToBucket returns the start of the bucket containing t, in UTC.
+// Weeks start on Monday, the same as the aggregation in the repository.
+func truncateToBucket(t time.Time, bucket domain.StatsBucket) time.Time {
+	t = t.UTC()
+
+	switch bucket {
+	case domain.StatsBucketHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.UTC)
+	case domain.StatsBucketWeek:
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// fillTimeline adds the empty buckets of the range, so the timeline can be plotted as is.
+func fillTimeline(points []domain.StatsPoint, query domain.ClickAnalyticsQuery) []domain.StatsPoint {
+	byStart := make(map[int64]domain.StatsPoint, len(points))
+	for _, p := range points {
+		byStart[p.Start.Unix()] = p
+	}
+
+	filled := make([]domain.StatsPoint, 0, len(points))
+	for start := truncateToBucket(query.From, query.Bucket); start.Before(query.To); start = nextBucket(start, query.Bucket) {
+		p := byStart[start.Unix()]
+		filled = append(filled, domain.StatsPoint{
+			Start:     start,
+			Clicks:    p.Clicks,
+			BotClicks: p.BotClicks,
+		})
+	}
+
+	return filled
+}
+
+func nextBucket(start time.Time, bucket domain.StatsBucket) time.Time {
+	switch bucket {
+	case domain.StatsBucketHour:
+		return start.Add(time.Hour)
+	case domain.StatsBucketWeek:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
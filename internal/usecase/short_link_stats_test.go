@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestFillTimeline(t *testing.T) {
+	query := domain.ClickAnalyticsQuery{
+		Bucket: domain.StatsBucketDay,
+		From:   time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC),
+		To:     time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC),
+	}
+	points := []domain.StatsPoint{
+		{Start: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), Clicks: 4},
+	}
+
+	got := fillTimeline(points, query)
+
+	expected := []domain.StatsPoint{
+		{Start: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Clicks: 0},
+		{Start: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), Clicks: 4},
+		{Start: time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC), Clicks: 0},
+		{Start: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC), Clicks: 0},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("fillTimeline() returned %d points; want %d", len(got), len(expected))
+	}
+	for i := range expected {
+		if !got[i].Start.Equal(expected[i].Start) || got[i].Clicks != expected[i].Clicks {
+			t.Errorf("point %d = %+v; want %+v", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestTruncateToBucketWeekStartsOnMonday(t *testing.T) {
+	// 2026-03-15 is a Sunday
+	got := truncateToBucket(time.Date(2026, 3, 15, 23, 0, 0, 0, time.UTC), domain.StatsBucketWeek)
+
+	expected := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(expected) {
+		t.Errorf("truncateToBucket() = %v; want %v", got, expected)
+	}
+}
+
+func TestNewClickAnalyticsQuery(t *testing.T) {
+	now := time.Date(2026, 3, 13, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		query       domain.StatsQuery
+		expectedErr error
+	}{
+		{name: "Defaults", query: domain.StatsQuery{Bucket: domain.StatsBucketDay}, expectedErr: nil},
+		{name: "Unknown Bucket", query: domain.StatsQuery{Bucket: "month"}, expectedErr: domain.ErrInvalidStatsQuery},
+		{name: "From After To", query: domain.StatsQuery{Bucket: domain.StatsBucketHour, From: now, To: now.Add(-time.Hour)}, expectedErr: domain.ErrInvalidStatsQuery},
+		{name: "Too Many Buckets", query: domain.StatsQuery{Bucket: domain.StatsBucketHour, From: now.AddDate(-1, 0, 0)}, expectedErr: domain.ErrInvalidStatsQuery},
+		{name: "Top Limit Too High", query: domain.StatsQuery{Bucket: domain.StatsBucketDay, TopLimit: maxStatsTopLimit + 1}, expectedErr: domain.ErrInvalidStatsQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newClickAnalyticsQuery(tt.query, now); err != tt.expectedErr {
+				t.Errorf("newClickAnalyticsQuery() error = %v; want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func (u *shortLinkUsecase) Restore(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	link, err := u.resolveLink(link)
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	restored, err := u.shortLinkRepo.RestoreOne(ctx, link)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.ShortLink{}, domain.ErrShortLinkNotFound
+		}
+
+		return domain.ShortLink{}, fmt.Errorf("Usecase.Restore (link: %s): %w", link, err)
+	}
+
+	// the deletion fields are already gone, the delete revision keeps who removed the link and when
+	u.recordRevision(ctx, domain.RevisionActionRestore, restored, restored)
+
+	return restored, nil
+}
+
+func (u *shortLinkUsecase) Purge(ctx context.Context, link domain.LinkRef) error {
+	link, err := u.resolveLink(link)
+	if err != nil {
+		return err
+	}
+
+	err = u.shortLinkRepo.PurgeOne(ctx, link)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.ErrShortLinkNotFound
+		}
+
+		return fmt.Errorf("Usecase.Purge (link: %s): %w", link, err)
+	}
+
+	return nil
+}
+
+func (u *shortLinkUsecase) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if olderThan < 0 {
+		return 0, domain.ErrInvalidPurgeAge
+	}
+
+	purged, err := u.shortLinkRepo.PurgeDeleted(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return purged, fmt.Errorf("Usecase.PurgeDeleted: %w", err)
+	}
+
+	return purged, nil
+}
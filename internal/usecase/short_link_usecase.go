@@ -6,8 +6,14 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/botdetect"
+	"github.com/OsoianMarcel/url-shortener/pkg/qrimage"
 	"github.com/OsoianMarcel/url-shortener/pkg/randlinkkey"
 )
 
@@ -16,39 +22,83 @@ var _ domain.ShortLinkUsecase = (*shortLinkUsecase)(nil)
 const (
 	createCircuitBreaker = 10
 	linkKeyLength        = 6
+	defaultListLimit     = 20
+	maxListLimit         = 100
 )
 
 type shortLinkUsecase struct {
-	logger        *slog.Logger
-	shortLinkRepo domain.ShortLinkRepo
-	buildShortURL func(key string) string
+	logger              *slog.Logger
+	shortLinkRepo       domain.ShortLinkRepo
+	clickEventRepo      domain.ClickEventRepo
+	uniqueVisitorRepo   domain.UniqueVisitorRepo
+	passwordAttemptRepo domain.PasswordAttemptRepo
+	linkRevisionRepo    domain.LinkRevisionRepo
+	clickRecorder       domain.ClickRecorder
+	botDetector         *botdetect.Detector
+	qrRenderer          *qrimage.Renderer
+	urlPolicy           domain.URLPolicy
+	redirectOptions     RedirectOptions
+	domains             Domains
+	buildShortURL       func(host string, key string) string
 }
 
 func NewShortLinkUsecase(
 	logger *slog.Logger,
 	shortLinkRepository domain.ShortLinkRepo,
-	buildShortURL func(key string) string,
+	clickEventRepository domain.ClickEventRepo,
+	uniqueVisitorRepository domain.UniqueVisitorRepo,
+	passwordAttemptRepository domain.PasswordAttemptRepo,
+	linkRevisionRepository domain.LinkRevisionRepo,
+	clickRecorder domain.ClickRecorder,
+	botDetector *botdetect.Detector,
+	qrRenderer *qrimage.Renderer,
+	urlPolicy domain.URLPolicy,
+	redirectOptions RedirectOptions,
+	domains Domains,
+	buildShortURL func(host string, key string) string,
 ) *shortLinkUsecase {
 	return &shortLinkUsecase{
-		logger:        logger,
-		shortLinkRepo: shortLinkRepository,
-		buildShortURL: buildShortURL,
+		logger:              logger,
+		shortLinkRepo:       shortLinkRepository,
+		clickEventRepo:      clickEventRepository,
+		uniqueVisitorRepo:   uniqueVisitorRepository,
+		passwordAttemptRepo: passwordAttemptRepository,
+		linkRevisionRepo:    linkRevisionRepository,
+		clickRecorder:       clickRecorder,
+		botDetector:         botDetector,
+		qrRenderer:          qrRenderer,
+		urlPolicy:           urlPolicy,
+		redirectOptions:     redirectOptions,
+		domains:             domains,
+		buildShortURL:       buildShortURL,
 	}
 }
 
 func (u *shortLinkUsecase) Create(ctx context.Context, createInput domain.CreateAction) (domain.CreateResult, error) {
-	// validate URL
-	parsedURL, err := url.ParseRequestURI(createInput.OriginalURL)
+	ent, err := u.prepareShortLink(ctx, createInput)
 	if err != nil {
-		return domain.CreateResult{}, domain.ErrInvalidURL
+		return domain.CreateResult{}, err
 	}
-	if parsedURL.Host == "" || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
-		return domain.CreateResult{}, domain.ErrInvalidURL
+
+	// a custom alias is inserted as is, a taken alias is reported to the caller
+	if createInput.Alias != "" {
+		id, err := u.shortLinkRepo.InsertOne(ctx, ent)
+		if err != nil {
+			if err == domain.ErrShortLinkKeyExists {
+				return domain.CreateResult{}, domain.ErrShortLinkKeyExists
+			}
+
+			return domain.CreateResult{}, fmt.Errorf("Usecase.Create: insert alias: %w", err)
+		}
+		ent.ID = id
+		u.recordRevision(ctx, domain.RevisionActionCreate, domain.ShortLink{}, ent)
+
+		return u.newCreateResult(ent), nil
 	}
 
 	for i := range createCircuitBreaker {
 		key := randlinkkey.GenLinkKey(linkKeyLength)
-		ent := domain.NewShortLink(key, createInput.OriginalURL)
+		ent.Key = key
 
 		id, err := u.shortLinkRepo.InsertOne(ctx, ent)
 		if err != nil {
@@ -65,72 +115,572 @@ func (u *shortLinkUsecase) Create(ctx context.Context, createInput domain.Create
 		}
 		// after inserting, set the entity ID
 		ent.ID = id
+		u.recordRevision(ctx, domain.RevisionActionCreate, domain.ShortLink{}, ent)
 
-		return domain.CreateResult{Key: ent.Key, ShortURL: u.buildShortURL(ent.Key)}, nil
+		return u.newCreateResult(ent), nil
 	}
 
 	return domain.CreateResult{}, errors.New("Usecase.Create: circuit breaker")
 }
 
-func (u *shortLinkUsecase) Delete(ctx context.Context, key string) error {
-	err := u.shortLinkRepo.DeleteOne(ctx, key)
+func (u *shortLinkUsecase) CreateMany(ctx context.Context, createInputs []domain.CreateAction) ([]domain.BulkCreateResult, error) {
+	if len(createInputs) == 0 || len(createInputs) > domain.MaxBulkCreateItems {
+		return nil, domain.ErrInvalidBatchSize
+	}
+
+	results := make([]domain.BulkCreateResult, len(createInputs))
+	ents := make([]domain.ShortLink, len(createInputs))
+	// hashing the passwords of a full batch one after another takes minutes, so the links are prepared in parallel
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i, createInput := range createInputs {
+		wg.Go(func() {
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			ents[i], results[i].Err = u.prepareShortLink(ctx, createInput)
+		})
+	}
+	wg.Wait()
+
+	created := make([]domain.ShortLink, 0, len(createInputs))
+
+	// indexes of the links left to insert
+	pending := make([]int, 0, len(createInputs))
+	for i := range ents {
+		if results[i].Err != nil {
+			continue
+		}
+		if ents[i].Key == "" {
+			ents[i].Key = randlinkkey.GenLinkKey(linkKeyLength)
+		}
+
+		pending = append(pending, i)
+	}
+
+	for attempt := 0; attempt < createCircuitBreaker && len(pending) > 0; attempt++ {
+		batch := make([]domain.ShortLink, 0, len(pending))
+		for _, i := range pending {
+			batch = append(batch, ents[i])
+		}
+
+		insertErrs, err := u.shortLinkRepo.InsertMany(ctx, batch)
+		if err != nil {
+			// the links inserted by the previous attempts are kept, so their results are returned
+			if attempt == 0 {
+				return nil, fmt.Errorf("Usecase.CreateMany: insert: %w", err)
+			}
+			for _, i := range pending {
+				results[i].Err = fmt.Errorf("Usecase.CreateMany: insert: %w", err)
+			}
+			u.recordCreations(ctx, created)
+
+			return results, nil
+		}
+
+		// only the generated keys are retried, a taken alias is reported to the caller
+		retry := pending[:0]
+		for j, i := range pending {
+			switch {
+			case insertErrs[j] == nil:
+				ents[i].ID = batch[j].ID
+				results[i].CreateResult = u.newCreateResult(ents[i])
+				created = append(created, ents[i])
+			case insertErrs[j] == domain.ErrShortLinkKeyExists && createInputs[i].Alias == "":
+				ents[i].Key = randlinkkey.GenLinkKey(linkKeyLength)
+				retry = append(retry, i)
+			default:
+				results[i].Err = insertErrs[j]
+			}
+		}
+		if len(retry) > 0 {
+			u.logger.Warn("link keys already exist",
+				slog.Int("count", len(retry)),
+				slog.Int("attempt", attempt),
+			)
+		}
+		pending = retry
+	}
+
+	for _, i := range pending {
+		results[i].Err = errors.New("Usecase.CreateMany: circuit breaker")
+	}
+	u.recordCreations(ctx, created)
+
+	return results, nil
+}
+
+// prepareShortLink validates the creation of a link and returns the link to insert,
+// its key is the alias, or empty when the key is generated.
+func (u *shortLinkUsecase) prepareShortLink(ctx context.Context, createInput domain.CreateAction) (domain.ShortLink, error) {
+	linkDomain, err := u.domains.resolve(createInput.Domain)
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	if err := u.checkOriginalURL(ctx, createInput.OriginalURL); err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	// validate expiration
+	if createInput.TTL < 0 || (createInput.TTL > 0 && !createInput.ExpiresAt.IsZero()) {
+		return domain.ShortLink{}, domain.ErrInvalidExpiration
+	}
+	if err := validateExpiresAt(createInput.ExpiresAt); err != nil {
+		return domain.ShortLink{}, err
+	}
+	if err := validateRedirectStatus(createInput.RedirectStatus); err != nil {
+		return domain.ShortLink{}, err
+	}
+	if err := validateQueryForwarding(createInput.QueryForwarding); err != nil {
+		return domain.ShortLink{}, err
+	}
+	if createInput.Alias != "" {
+		if err := validateAlias(createInput.Alias); err != nil {
+			return domain.ShortLink{}, err
+		}
+	}
+
+	passwordHash := ""
+	if createInput.Password != "" {
+		passwordHash, err = hashLinkPassword(createInput.Password)
+		if err != nil {
+			return domain.ShortLink{}, err
+		}
+	}
+
+	ent := domain.NewShortLink(createInput.Alias, createInput.OriginalURL)
+	ent.Domain = linkDomain
+	ent.MaxHits = createInput.MaxHits
+	ent.PasswordHash = passwordHash
+	ent.RedirectStatus = createInput.RedirectStatus
+	ent.QueryForwarding = createInput.QueryForwarding
+	ent.ForwardPath = createInput.ForwardPath
+	ent.Disabled = createInput.Disabled
+	if createInput.TTL > 0 {
+		ent.ExpiresAt = ent.CreatedAt.Add(createInput.TTL)
+	} else {
+		ent.ExpiresAt = createInput.ExpiresAt
+	}
+
+	return ent, nil
+}
+
+func (u *shortLinkUsecase) newCreateResult(ent domain.ShortLink) domain.CreateResult {
+	return domain.CreateResult{
+		Domain:    ent.Domain,
+		Key:       ent.Key,
+		ShortURL:  u.buildShortURL(u.domains.host(ent.Domain), ent.Key),
+		ExpiresAt: ent.ExpiresAt,
+	}
+}
+
+func (u *shortLinkUsecase) Update(ctx context.Context, updateInput domain.UpdateAction) (domain.ShortLink, error) {
+	linkDomain, err := u.domains.resolve(updateInput.Domain)
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+	link := domain.LinkRef{Domain: linkDomain, Key: updateInput.Key}
+
+	ent, err := u.shortLinkRepo.FindOne(ctx, link)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.ShortLink{}, domain.ErrShortLinkNotFound
+		}
+
+		return domain.ShortLink{}, fmt.Errorf("Usecase.Update (link: %s): find: %w", link, err)
+	}
+	// the link before the update, for its revision
+	before := ent
+
+	if updateInput.OriginalURL != nil {
+		if err := u.checkOriginalURL(ctx, *updateInput.OriginalURL); err != nil {
+			return domain.ShortLink{}, err
+		}
+		ent.OriginalURL = *updateInput.OriginalURL
+	}
+
+	if updateInput.ExpiresAt != nil && updateInput.TTL != nil {
+		return domain.ShortLink{}, domain.ErrInvalidExpiration
+	}
+	if updateInput.ExpiresAt != nil {
+		if err := validateExpiresAt(*updateInput.ExpiresAt); err != nil {
+			return domain.ShortLink{}, err
+		}
+		ent.ExpiresAt = *updateInput.ExpiresAt
+	}
+	if updateInput.TTL != nil {
+		if *updateInput.TTL <= 0 {
+			return domain.ShortLink{}, domain.ErrInvalidExpiration
+		}
+		ent.ExpiresAt = time.Now().Add(*updateInput.TTL)
+	}
+
+	if updateInput.MaxHits != nil {
+		ent.MaxHits = *updateInput.MaxHits
+	}
+
+	if updateInput.Password != nil {
+		ent.PasswordHash = ""
+		if *updateInput.Password != "" {
+			ent.PasswordHash, err = hashLinkPassword(*updateInput.Password)
+			if err != nil {
+				return domain.ShortLink{}, err
+			}
+		}
+	}
+
+	if updateInput.RedirectStatus != nil {
+		if err := validateRedirectStatus(*updateInput.RedirectStatus); err != nil {
+			return domain.ShortLink{}, err
+		}
+		ent.RedirectStatus = *updateInput.RedirectStatus
+	}
+	if updateInput.QueryForwarding != nil {
+		if err := validateQueryForwarding(*updateInput.QueryForwarding); err != nil {
+			return domain.ShortLink{}, err
+		}
+		ent.QueryForwarding = *updateInput.QueryForwarding
+	}
+	if updateInput.ForwardPath != nil {
+		ent.ForwardPath = *updateInput.ForwardPath
+	}
+
+	updated, err := u.shortLinkRepo.UpdateOne(ctx, ent)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.ShortLink{}, domain.ErrShortLinkNotFound
+		}
+
+		return domain.ShortLink{}, fmt.Errorf("Usecase.Update (link: %s): update: %w", link, err)
+	}
+	u.recordRevision(ctx, domain.RevisionActionUpdate, before, updated)
+
+	return updated, nil
+}
+
+func (u *shortLinkUsecase) Delete(ctx context.Context, link domain.LinkRef) error {
+	link, err := u.resolveLink(link)
+	if err != nil {
+		return err
+	}
+
+	// the CLI runs without a principal, its deletions are recorded without an owner
+	deleted, err := u.shortLinkRepo.DeleteOne(ctx, link, domain.ActorFromContext(ctx).Owner)
 	if err != nil {
 		if err == domain.ErrShortLinkNotFound {
 			return domain.ErrShortLinkNotFound
 		}
 
-		return fmt.Errorf("Usecase.Delete (key: %s): %w", key, err)
+		return fmt.Errorf("Usecase.Delete (link: %s): %w", link, err)
 	}
 
+	active := deleted
+	active.DeletedAt, active.DeletedBy = time.Time{}, ""
+	u.recordRevision(ctx, domain.RevisionActionDelete, active, deleted)
+
 	return nil
 }
 
-func (u *shortLinkUsecase) Expand(ctx context.Context, key string) (domain.ShortLink, error) {
-	shortURL, err := u.shortLinkRepo.FindOne(ctx, key)
+func (u *shortLinkUsecase) Expand(ctx context.Context, link domain.LinkRef, attempt domain.PasswordAttempt) (domain.ShortLink, error) {
+	link, err := u.resolveLink(link)
+	if err != nil {
+		return domain.ShortLink{}, err
+	}
+
+	return u.expand(ctx, link, attempt)
+}
+
+func (u *shortLinkUsecase) Preview(ctx context.Context, link domain.LinkRef, attempt domain.PasswordAttempt) (domain.ShortLink, error) {
+	// the preview is served next to the redirect, so its host is resolved the same way
+	link = domain.LinkRef{Domain: u.domains.resolveHost(link.Domain), Key: link.Key}
+
+	return u.expand(ctx, link, attempt)
+}
+
+// expand returns the link of the resolved reference when it may be followed with the attempt.
+func (u *shortLinkUsecase) expand(ctx context.Context, link domain.LinkRef, attempt domain.PasswordAttempt) (domain.ShortLink, error) {
+	shortURL, err := u.shortLinkRepo.FindOne(ctx, link)
 
 	if err != nil {
 		if err == domain.ErrShortLinkNotFound {
 			return domain.ShortLink{}, domain.ErrShortLinkNotFound
 		}
 
-		return domain.ShortLink{}, fmt.Errorf("Usecase.Expand (key: %s): %w", key, err)
+		return domain.ShortLink{}, fmt.Errorf("Usecase.Expand (link: %s): %w", link, err)
+	}
+
+	if shortURL.IsExpired(time.Now()) {
+		return domain.ShortLink{}, domain.ErrShortLinkExpired
+	}
+	if shortURL.IsExhausted() {
+		return domain.ShortLink{}, domain.ErrShortLinkExhausted
+	}
+	if shortURL.Disabled {
+		return domain.ShortLink{}, domain.ErrShortLinkDisabled
+	}
+
+	if err := u.checkPassword(ctx, link, shortURL.PasswordHash, attempt); err != nil {
+		if isPasswordError(err) {
+			return domain.ShortLink{}, err
+		}
+
+		return domain.ShortLink{}, fmt.Errorf("Usecase.Expand (link: %s): %w", link, err)
 	}
 
 	return shortURL, nil
 }
 
-func (u *shortLinkUsecase) OriginalURL(ctx context.Context, key string) (string, error) {
-	originalURL, err := u.shortLinkRepo.FindOriginalURL(ctx, key)
+func (u *shortLinkUsecase) Redirect(ctx context.Context, request domain.RedirectRequest) (domain.Redirect, error) {
+	link := domain.LinkRef{Domain: u.domains.resolveHost(request.Domain), Key: request.Key}
+	visit := request.Visit
+	target, err := u.shortLinkRepo.FindRedirectTarget(ctx, link)
 
 	if err != nil {
-		if err == domain.ErrShortLinkNotFound {
-			return "", domain.ErrShortLinkNotFound
+		if err == domain.ErrShortLinkNotFound || err == domain.ErrShortLinkExpired || err == domain.ErrShortLinkExhausted {
+			return domain.Redirect{}, err
+		}
+
+		return domain.Redirect{}, fmt.Errorf("Usecase.Redirect (link: %s): %w", link, err)
+	}
+
+	// a path below the key of a link without path forwarding is not a link
+	if request.PathSuffix != "" && !target.ForwardPath {
+		return domain.Redirect{}, domain.ErrShortLinkNotFound
+	}
+	// checked before the password, so a disabled link neither counts hits nor reveals if it is protected
+	if target.Disabled {
+		return domain.Redirect{}, domain.ErrShortLinkDisabled
+	}
+
+	// the visit is recorded only once the password is accepted
+	err = u.checkPassword(ctx, link, target.PasswordHash, domain.PasswordAttempt{Password: request.Password, ClientIP: visit.IP})
+	if err != nil {
+		if isPasswordError(err) {
+			return domain.Redirect{}, err
 		}
 
-		return "", fmt.Errorf("Usecase.OriginalURL (key: %s): %w", key, err)
+		return domain.Redirect{}, fmt.Errorf("Usecase.Redirect (link: %s): %w", link, err)
 	}
 
-	err = u.shortLinkRepo.IncreaseHits(ctx, key)
+	destination, err := forwardRequest(target, request.PathSuffix, request.RawQuery)
 	if err != nil {
-		u.logger.Warn("failed to increase the link hits, continue", slog.Any("err", err))
+		return domain.Redirect{}, fmt.Errorf("Usecase.Redirect (link: %s): %w", link, err)
+	}
+
+	// bots are redirected as well, but they neither use up the hit limit nor count as visitors
+	bot := u.botDetector.IsBot(visit.UserAgent, visit.Purpose)
+	if bot {
+		err = u.shortLinkRepo.IncreaseBotHits(ctx, link)
+		if err != nil {
+			u.logger.Warn("failed to increase the link bot hits, continue", slog.Any("err", err))
+		}
+	} else {
+		// the increment is conditional for links with a hit limit, so it acts as the final check
+		err = u.shortLinkRepo.IncreaseHits(ctx, link, target.MaxHits > 0)
+		if err != nil {
+			if err == domain.ErrShortLinkNotFound || err == domain.ErrShortLinkExhausted {
+				return domain.Redirect{}, err
+			}
+
+			u.logger.Warn("failed to increase the link hits, continue", slog.Any("err", err))
+		}
+
+		err = u.uniqueVisitorRepo.Add(ctx, target.LinkID, visit.VisitorID(), time.Now())
+		if err != nil {
+			u.logger.Warn("failed to count the unique visitor, continue", slog.Any("err", err))
+		}
 	}
 
-	return originalURL, nil
+	u.clickRecorder.Record(domain.NewClickEvent(link, target.LinkID, visit, bot))
+
+	redirect := newRedirect(target, u.redirectOptions, time.Now())
+	redirect.URL = destination
+
+	return redirect, nil
 }
 
-func (u *shortLinkUsecase) Stats(ctx context.Context, key string) (domain.StatsResult, error) {
-	statsModel, err := u.shortLinkRepo.FindStats(ctx, key)
+func (u *shortLinkUsecase) Stats(ctx context.Context, query domain.StatsQuery) (domain.StatsResult, error) {
+	var analyticsQuery domain.ClickAnalyticsQuery
+	if query.Bucket != "" {
+		var err error
+		analyticsQuery, err = newClickAnalyticsQuery(query, time.Now())
+		if err != nil {
+			return domain.StatsResult{}, err
+		}
+	}
+
+	link, err := u.resolveLink(domain.LinkRef{Domain: query.Domain, Key: query.Key})
+	if err != nil {
+		return domain.StatsResult{}, err
+	}
+	analyticsQuery.LinkDomain = link.Domain
+
+	statsModel, err := u.shortLinkRepo.FindStats(ctx, link)
 
 	if err != nil {
 		if err == domain.ErrShortLinkNotFound {
 			return domain.StatsResult{}, domain.ErrShortLinkNotFound
 		}
 
-		return domain.StatsResult{}, fmt.Errorf("Usecase.Stats (key: %s): %w", key, err)
+		return domain.StatsResult{}, fmt.Errorf("Usecase.Stats (link: %s): %w", link, err)
+	}
+
+	uniqueVisitors, err := u.uniqueVisitorRepo.Count(ctx, statsModel.LinkID, statsModel.CreatedAt, time.Now())
+	if err != nil {
+		return domain.StatsResult{}, fmt.Errorf("Usecase.Stats (link: %s): count unique visitors: %w", link, err)
+	}
+
+	result := domain.StatsResult{
+		LinkID:         statsModel.LinkID,
+		Hits:           statsModel.Hits,
+		BotHits:        statsModel.BotHits,
+		UniqueVisitors: uniqueVisitors,
+		MaxHits:        statsModel.MaxHits,
+		CreatedAt:      statsModel.CreatedAt,
+	}
+
+	if query.Bucket == "" {
+		return result, nil
+	}
+
+	analyticsQuery.LinkID = statsModel.LinkID
+	analyticsQuery.LinkCreatedAt = statsModel.CreatedAt
+	analytics, err := u.clickEventRepo.Aggregate(ctx, analyticsQuery)
+	if err != nil {
+		return domain.StatsResult{}, fmt.Errorf("Usecase.Stats (link: %s): aggregate clicks: %w", link, err)
+	}
+	analytics.Timeline = fillTimeline(analytics.Timeline, analyticsQuery)
+	result.Analytics = analytics
+
+	return result, nil
+}
+
+func (u *shortLinkUsecase) List(ctx context.Context, query domain.ListQuery) (domain.ListResult, error) {
+	switch query.SortBy {
+	case "":
+		query.SortBy = domain.ListSortByCreatedAt
+	case domain.ListSortByCreatedAt, domain.ListSortByHits:
+	default:
+		return domain.ListResult{}, domain.ErrInvalidListQuery
+	}
+
+	if query.Limit < 0 || query.Limit > maxListLimit {
+		return domain.ListResult{}, domain.ErrInvalidListQuery
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultListLimit
+	}
+
+	if !query.CreatedAfter.IsZero() && !query.CreatedBefore.IsZero() && !query.CreatedAfter.Before(query.CreatedBefore) {
+		return domain.ListResult{}, domain.ErrInvalidListQuery
+	}
+
+	query.Host = strings.ToLower(query.Host)
+
+	result, err := u.shortLinkRepo.FindMany(ctx, query)
+	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			return domain.ListResult{}, domain.ErrInvalidCursor
+		}
+
+		return domain.ListResult{}, fmt.Errorf("Usecase.List: %w", err)
+	}
+
+	return result, nil
+}
+
+func (u *shortLinkUsecase) Export(ctx context.Context, query domain.ExportQuery, fn func(domain.ShortLink) error) error {
+	if !query.CreatedAfter.IsZero() && !query.CreatedBefore.IsZero() && !query.CreatedAfter.Before(query.CreatedBefore) {
+		return domain.ErrInvalidExportQuery
+	}
+
+	filter := domain.ExportFilter{
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+	}
+	if query.Domain != "" {
+		linkDomain, err := u.domains.resolve(query.Domain)
+		if err != nil {
+			return err
+		}
+		filter.Domain = &linkDomain
+	}
+
+	if err := u.shortLinkRepo.Export(ctx, filter, fn); err != nil {
+		return fmt.Errorf("Usecase.Export: %w", err)
+	}
+
+	return nil
+}
+
+func (u *shortLinkUsecase) Clicks(ctx context.Context, query domain.ClickQuery) (domain.ClickResult, error) {
+	if query.Limit < 0 || query.Limit > maxListLimit {
+		return domain.ClickResult{}, domain.ErrInvalidListQuery
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultListLimit
+	}
+
+	link, err := u.resolveLink(domain.LinkRef{Domain: query.LinkDomain, Key: query.LinkKey})
+	if err != nil {
+		return domain.ClickResult{}, err
+	}
+	query.LinkDomain = link.Domain
+
+	ent, err := u.shortLinkRepo.FindOne(ctx, link)
+	if err != nil {
+		if err == domain.ErrShortLinkNotFound {
+			return domain.ClickResult{}, domain.ErrShortLinkNotFound
+		}
+
+		return domain.ClickResult{}, fmt.Errorf("Usecase.Clicks (link: %s): find link: %w", link, err)
+	}
+	// the events of a previous link with the same key are not returned
+	query.LinkID = ent.ID
+	query.LinkCreatedAt = ent.CreatedAt
+
+	result, err := u.clickEventRepo.FindMany(ctx, query)
+	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			return domain.ClickResult{}, domain.ErrInvalidCursor
+		}
+
+		return domain.ClickResult{}, fmt.Errorf("Usecase.Clicks (link: %s): %w", link, err)
+	}
+
+	return result, nil
+}
+
+// resolveLink replaces the requested domain of the link with its stored form.
+func (u *shortLinkUsecase) resolveLink(link domain.LinkRef) (domain.LinkRef, error) {
+	linkDomain, err := u.domains.resolve(link.Domain)
+	if err != nil {
+		return domain.LinkRef{}, err
+	}
+
+	return domain.LinkRef{Domain: linkDomain, Key: link.Key}, nil
+}
+
+// checkOriginalURL validates the URL and applies the destination policy to it.
+func (u *shortLinkUsecase) checkOriginalURL(ctx context.Context, originalURL string) error {
+	parsedURL, err := validateOriginalURL(originalURL)
+	if err != nil {
+		return err
+	}
+
+	return u.urlPolicy.Check(ctx, originalURL, parsedURL)
+}
+
+// validateOriginalURL accepts only absolute http(s) URLs with a host.
+func validateOriginalURL(originalURL string) (*url.URL, error) {
+	parsedURL, err := url.ParseRequestURI(originalURL)
+	if err != nil {
+		return nil, domain.ErrInvalidURL
+	}
+	if parsedURL.Host == "" || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return nil, domain.ErrInvalidURL
 	}
 
-	return domain.StatsResult{
-		Hits:      statsModel.Hits,
-		CreatedAt: statsModel.CreatedAt,
-	}, nil
+	return parsedURL, nil
 }
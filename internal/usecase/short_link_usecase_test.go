@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+	"github.com/OsoianMarcel/url-shortener/pkg/botdetect"
+)
+
+// fakeShortLinkRepo keeps a single link in memory and increments its hits
+// with the same condition as the Mongo repository.
+type fakeShortLinkRepo struct {
+	domain.ShortLinkRepo
+	link domain.ShortLink
+	// cached skips the hit limit check on lookup, like a cached original URL
+	cached bool
+}
+
+func (r *fakeShortLinkRepo) FindRedirectTarget(ctx context.Context, link domain.LinkRef) (domain.RedirectTarget, error) {
+	if link != r.link.Ref() {
+		return domain.RedirectTarget{}, domain.ErrShortLinkNotFound
+	}
+	if !r.cached && r.link.IsExhausted() {
+		return domain.RedirectTarget{}, domain.ErrShortLinkExhausted
+	}
+
+	return domain.RedirectTarget{LinkID: r.link.ID, OriginalURL: r.link.OriginalURL, Disabled: r.link.Disabled}, nil
+}
+
+func (r *fakeShortLinkRepo) FindOne(ctx context.Context, link domain.LinkRef) (domain.ShortLink, error) {
+	if link != r.link.Ref() {
+		return domain.ShortLink{}, domain.ErrShortLinkNotFound
+	}
+
+	return r.link, nil
+}
+
+func (r *fakeShortLinkRepo) IncreaseHits(ctx context.Context, link domain.LinkRef, limited bool) error {
+	if link != r.link.Ref() {
+		return domain.ErrShortLinkNotFound
+	}
+	if r.link.IsExhausted() {
+		return domain.ErrShortLinkExhausted
+	}
+	r.link.Hits++
+
+	return nil
+}
+
+type fakeUniqueVisitorRepo struct {
+	domain.UniqueVisitorRepo
+}
+
+func (fakeUniqueVisitorRepo) Add(ctx context.Context, linkID string, visitorID string, at time.Time) error {
+	return nil
+}
+
+type fakeClickRecorder struct {
+	events []domain.ClickEvent
+}
+
+func (r *fakeClickRecorder) Record(event domain.ClickEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestRedirectMaxHits(t *testing.T) {
+	tests := []struct {
+		name         string
+		hits         uint
+		maxHits      uint
+		cached       bool
+		expectedErr  error
+		expectedHits uint
+	}{
+		{name: "No Limit", hits: 10, maxHits: 0, expectedErr: nil, expectedHits: 11},
+		{name: "Below Limit", hits: 0, maxHits: 2, expectedErr: nil, expectedHits: 1},
+		{name: "Last Allowed Hit", hits: 1, maxHits: 2, expectedErr: nil, expectedHits: 2},
+		{name: "Exhausted", hits: 2, maxHits: 2, expectedErr: domain.ErrShortLinkExhausted, expectedHits: 2},
+		{name: "Exhausted With Cached URL", hits: 2, maxHits: 2, cached: true, expectedErr: domain.ErrShortLinkExhausted, expectedHits: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeShortLinkRepo{
+				link: domain.ShortLink{
+					ID:          "65f1a2b3c4d5e6f708091a2b",
+					Key:         "abc",
+					OriginalURL: "https://example.com",
+					Hits:        tt.hits,
+					MaxHits:     tt.maxHits,
+				},
+				cached: tt.cached,
+			}
+			clickRecorder := &fakeClickRecorder{}
+			u := NewShortLinkUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil, fakeUniqueVisitorRepo{}, nil, nil, clickRecorder, botdetect.New(nil), nil, nil, RedirectOptions{DefaultStatus: http.StatusFound}, Domains{}, nil)
+
+			redirect, err := u.Redirect(context.Background(), domain.RedirectRequest{Key: "abc"})
+			if err != tt.expectedErr {
+				t.Fatalf("Redirect() error = %v; want %v", err, tt.expectedErr)
+			}
+			if err == nil && redirect.URL != "https://example.com" {
+				t.Errorf("Redirect() URL = %q; want %q", redirect.URL, "https://example.com")
+			}
+			if repo.link.Hits != tt.expectedHits {
+				t.Errorf("hits = %d; want %d", repo.link.Hits, tt.expectedHits)
+			}
+			// a refused redirect is not a click
+			if recorded := len(clickRecorder.events) == 1; recorded != (err == nil) {
+				t.Errorf("recorded clicks = %d; want a click only for a successful redirect", len(clickRecorder.events))
+			}
+			// a key reused by a later link must not inherit the clicks of this one
+			if len(clickRecorder.events) == 1 && clickRecorder.events[0].LinkID != repo.link.ID {
+				t.Errorf("click link ID = %q; want %q", clickRecorder.events[0].LinkID, repo.link.ID)
+			}
+		})
+	}
+}
+
+func TestRedirectExhaustsLink(t *testing.T) {
+	repo := &fakeShortLinkRepo{
+		link: domain.ShortLink{Key: "abc", OriginalURL: "https://example.com", MaxHits: 3},
+	}
+	u := NewShortLinkUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil, fakeUniqueVisitorRepo{}, nil, nil, &fakeClickRecorder{}, botdetect.New(nil), nil, nil, RedirectOptions{DefaultStatus: http.StatusFound}, Domains{}, nil)
+
+	for i := uint(1); i <= repo.link.MaxHits; i++ {
+		if _, err := u.Redirect(context.Background(), domain.RedirectRequest{Key: "abc"}); err != nil {
+			t.Fatalf("hit %d: Redirect() error = %v; want nil", i, err)
+		}
+	}
+	if _, err := u.Redirect(context.Background(), domain.RedirectRequest{Key: "abc"}); err != domain.ErrShortLinkExhausted {
+		t.Errorf("hit %d: Redirect() error = %v; want %v", repo.link.MaxHits+1, err, domain.ErrShortLinkExhausted)
+	}
+}
+
+func TestDisabledLinkIsRefused(t *testing.T) {
+	repo := &fakeShortLinkRepo{
+		link: domain.ShortLink{Key: "abc", OriginalURL: "https://example.com", Hits: 3, Disabled: true},
+	}
+	clickRecorder := &fakeClickRecorder{}
+	u := NewShortLinkUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil, fakeUniqueVisitorRepo{}, nil, nil, clickRecorder, botdetect.New(nil), nil, nil, RedirectOptions{DefaultStatus: http.StatusFound}, Domains{}, nil)
+	ctx := context.Background()
+
+	if _, err := u.Redirect(ctx, domain.RedirectRequest{Key: "abc"}); err != domain.ErrShortLinkDisabled {
+		t.Errorf("Redirect() error = %v; want %v", err, domain.ErrShortLinkDisabled)
+	}
+	if _, err := u.Expand(ctx, domain.LinkRef{Key: "abc"}, domain.PasswordAttempt{}); err != domain.ErrShortLinkDisabled {
+		t.Errorf("Expand() error = %v; want %v", err, domain.ErrShortLinkDisabled)
+	}
+	if _, err := u.Preview(ctx, domain.LinkRef{Key: "abc"}, domain.PasswordAttempt{}); err != domain.ErrShortLinkDisabled {
+		t.Errorf("Preview() error = %v; want %v", err, domain.ErrShortLinkDisabled)
+	}
+
+	// a disabled link is not visited
+	if repo.link.Hits != 3 {
+		t.Errorf("hits = %d; want 3", repo.link.Hits)
+	}
+	if len(clickRecorder.events) != 0 {
+		t.Errorf("recorded clicks = %d; want 0", len(clickRecorder.events))
+	}
+}
+
+// fakeBulkShortLinkRepo inserts links into an in-memory key set and reports
+// a taken key per link, like the unordered Mongo InsertMany.
+type fakeBulkShortLinkRepo struct {
+	domain.ShortLinkRepo
+	keys map[string]bool
+	// collide rejects the first insert of the links with these original URLs, like a taken generated key
+	collide map[string]bool
+	batches [][]domain.ShortLink
+}
+
+func (r *fakeBulkShortLinkRepo) InsertMany(ctx context.Context, shortLinks []domain.ShortLink) ([]error, error) {
+	r.batches = append(r.batches, append([]domain.ShortLink(nil), shortLinks...))
+
+	errs := make([]error, len(shortLinks))
+	for i := range shortLinks {
+		link := &shortLinks[i]
+		if r.collide[link.OriginalURL] {
+			delete(r.collide, link.OriginalURL)
+			errs[i] = domain.ErrShortLinkKeyExists
+			continue
+		}
+		if r.keys[link.Key] {
+			errs[i] = domain.ErrShortLinkKeyExists
+			continue
+		}
+		r.keys[link.Key] = true
+		link.ID = "id-" + link.Key
+	}
+
+	return errs, nil
+}
+
+type fakeLinkRevisionRepo struct {
+	domain.LinkRevisionRepo
+	revisions []domain.LinkRevision
+}
+
+func (r *fakeLinkRevisionRepo) InsertMany(ctx context.Context, revisions []domain.LinkRevision) error {
+	r.revisions = append(r.revisions, revisions...)
+
+	return nil
+}
+
+type allowURLPolicy struct{}
+
+func (allowURLPolicy) Check(ctx context.Context, originalURL string, destination *url.URL) error {
+	return nil
+}
+
+func TestCreateMany(t *testing.T) {
+	repo := &fakeBulkShortLinkRepo{
+		keys:    map[string]bool{"taken": true},
+		collide: map[string]bool{"https://example.com/collide": true},
+	}
+	revisionRepo := &fakeLinkRevisionRepo{}
+	buildShortURL := func(host string, key string) string { return "https://" + host + "/" + key }
+	u := NewShortLinkUsecase(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil, fakeUniqueVisitorRepo{}, nil, revisionRepo, &fakeClickRecorder{}, botdetect.New(nil), nil, allowURLPolicy{}, RedirectOptions{DefaultStatus: http.StatusFound}, Domains{}, buildShortURL)
+
+	inputs := []domain.CreateAction{
+		{OriginalURL: "https://example.com/collide"},
+		{OriginalURL: "https://example.com/taken", Alias: "taken"},
+		{OriginalURL: "https://example.com/promo", Alias: "promo"},
+		{OriginalURL: "https://example.com/promo-again", Alias: "promo"},
+		{OriginalURL: "not a url"},
+		{OriginalURL: "https://example.com/generated"},
+	}
+	results, err := u.CreateMany(context.Background(), inputs)
+	if err != nil {
+		t.Fatalf("CreateMany() error = %v; want nil", err)
+	}
+
+	wantErrs := []error{nil, domain.ErrShortLinkKeyExists, nil, domain.ErrShortLinkKeyExists, domain.ErrInvalidURL, nil}
+	for i, want := range wantErrs {
+		if results[i].Err != want {
+			t.Errorf("results[%d].Err = %v; want %v", i, results[i].Err, want)
+		}
+		if want == nil && (results[i].Key == "" || results[i].ShortURL == "") {
+			t.Errorf("results[%d] = %+v; want a key and a short URL", i, results[i].CreateResult)
+		}
+	}
+	if results[2].Key != "promo" {
+		t.Errorf("results[2].Key = %q; want %q", results[2].Key, "promo")
+	}
+
+	// the invalid URL is never inserted and only the colliding generated key is retried
+	if len(repo.batches) != 2 {
+		t.Fatalf("InsertMany calls = %d; want 2", len(repo.batches))
+	}
+	if len(repo.batches[0]) != 5 {
+		t.Errorf("first batch size = %d; want 5", len(repo.batches[0]))
+	}
+	if len(repo.batches[1]) != 1 || repo.batches[1][0].OriginalURL != inputs[0].OriginalURL {
+		t.Errorf("retried batch = %+v; want only %q", repo.batches[1], inputs[0].OriginalURL)
+	}
+	if repo.batches[1][0].Key == repo.batches[0][0].Key {
+		t.Errorf("retried key = %q; want a new key", repo.batches[1][0].Key)
+	}
+	if len(revisionRepo.revisions) != 3 {
+		t.Errorf("recorded revisions = %d; want one per created link", len(revisionRepo.revisions))
+	}
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+// DefaultShortenerDomains are the well known URL shorteners, links to them hide the final destination.
+var DefaultShortenerDomains = []string{
+	"bit.ly",
+	"bitly.com",
+	"buff.ly",
+	"cutt.ly",
+	"goo.gl",
+	"is.gd",
+	"ow.ly",
+	"rebrand.ly",
+	"shorturl.at",
+	"t.co",
+	"t.ly",
+	"tiny.cc",
+	"tinyurl.com",
+	"v.gd",
+}
+
+var (
+	_ domain.URLPolicy = (urlPolicyChain)(nil)
+	_ domain.URLPolicy = (*maxLengthPolicy)(nil)
+	_ domain.URLPolicy = (*privateAddressPolicy)(nil)
+	_ domain.URLPolicy = (*shortenerPolicy)(nil)
+)
+
+// urlPolicyChain runs the policies in order and returns the first rejection.
+type urlPolicyChain []domain.URLPolicy
+
+// NewURLPolicy combines the policies, nil policies are skipped.
+func NewURLPolicy(policies ...domain.URLPolicy) domain.URLPolicy {
+	chain := make(urlPolicyChain, 0, len(policies))
+	for _, policy := range policies {
+		if policy != nil {
+			chain = append(chain, policy)
+		}
+	}
+
+	return chain
+}
+
+func (c urlPolicyChain) Check(ctx context.Context, originalURL string, destination *url.URL) error {
+	for _, policy := range c {
+		if err := policy.Check(ctx, originalURL, destination); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type maxLengthPolicy struct {
+	maxLength int
+}
+
+// NewMaxLengthPolicy rejects the URLs longer than maxLength bytes.
+func NewMaxLengthPolicy(maxLength int) *maxLengthPolicy {
+	return &maxLengthPolicy{maxLength: maxLength}
+}
+
+// Check measures the stored URL, the serialized destination may be escaped or normalized differently.
+func (p *maxLengthPolicy) Check(_ context.Context, originalURL string, _ *url.URL) error {
+	if len(originalURL) > p.maxLength {
+		return domain.ErrURLTooLong
+	}
+
+	return nil
+}
+
+type privateAddressPolicy struct{}
+
+// NewPrivateAddressPolicy rejects the URLs whose host is a loopback, private, link-local
+// or unspecified IP address, including the numeric IPv4 forms browsers accept, like "2130706433".
+// Host names are not resolved, so a public name pointing to a private address is accepted.
+func NewPrivateAddressPolicy() *privateAddressPolicy {
+	return &privateAddressPolicy{}
+}
+
+func (p *privateAddressPolicy) Check(_ context.Context, _ string, destination *url.URL) error {
+	host := strings.TrimSuffix(strings.ToLower(destination.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return domain.ErrPrivateAddress
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		var ok bool
+		if addr, ok = parseNumericIPv4(host); !ok {
+			return nil
+		}
+	}
+	addr = addr.Unmap()
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return domain.ErrPrivateAddress
+	}
+
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, not covered by netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// parseNumericIPv4 parses the IPv4 forms of the WHATWG URL standard: one to four dot separated
+// decimal, octal ("0" prefix) or hexadecimal ("0x" prefix) numbers, the last one filling the remaining bytes.
+func parseNumericIPv4(host string) (netip.Addr, bool) {
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return netip.Addr{}, false
+	}
+
+	numbers := make([]uint64, len(parts))
+	for i, part := range parts {
+		base := 10
+		switch {
+		case strings.HasPrefix(part, "0x") || strings.HasPrefix(part, "0X"):
+			base, part = 16, part[2:]
+		case len(part) > 1 && part[0] == '0':
+			base, part = 8, part[1:]
+		}
+		if part == "" && base != 16 {
+			return netip.Addr{}, false
+		}
+		if part == "" {
+			part = "0"
+		}
+
+		number, err := strconv.ParseUint(part, base, 32)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		numbers[i] = number
+	}
+
+	last := len(numbers) - 1
+	if numbers[last] >= 1<<(8*(4-last)) {
+		return netip.Addr{}, false
+	}
+
+	value := numbers[last]
+	for i := range last {
+		if numbers[i] > 255 {
+			return netip.Addr{}, false
+		}
+		value |= numbers[i] << (8 * (3 - i))
+	}
+
+	return netip.AddrFrom4([4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)}), true
+}
+
+type shortenerPolicy struct {
+	domains []string
+}
+
+// NewShortenerPolicy rejects the URLs pointing to the domains or their subdomains,
+// which prevents redirect loops through this service and chains of shorteners hiding the destination.
+func NewShortenerPolicy(domains []string) *shortenerPolicy {
+	normalized := make([]string, 0, len(domains))
+	for _, d := range domains {
+		if d = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), "."); d != "" {
+			normalized = append(normalized, d)
+		}
+	}
+
+	return &shortenerPolicy{domains: normalized}
+}
+
+func (p *shortenerPolicy) Check(_ context.Context, _ string, destination *url.URL) error {
+	host := strings.TrimSuffix(strings.ToLower(destination.Hostname()), ".")
+
+	for _, d := range p.domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return domain.ErrShortenerURL
+		}
+	}
+
+	return nil
+}
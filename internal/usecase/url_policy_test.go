@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/internal/domain"
+)
+
+func TestURLPolicy(t *testing.T) {
+	policy := NewURLPolicy(
+		NewMaxLengthPolicy(64),
+		NewPrivateAddressPolicy(),
+		NewShortenerPolicy([]string{"sho.rt", "bit.ly"}),
+	)
+
+	tests := []struct {
+		url         string
+		expectedErr error
+	}{
+		{url: "https://example.com/page", expectedErr: nil},
+		{url: "https://8.8.8.8/", expectedErr: nil},
+		{url: "https://[2001:4860:4860::8888]/", expectedErr: nil},
+		{url: "https://example.com/" + strings.Repeat("a", 64), expectedErr: domain.ErrURLTooLong},
+		// the stored URL is measured, not its escaped or normalized serialization
+		{url: "https://example.com/" + strings.Repeat("ä", 20), expectedErr: nil},
+		{url: "https://example.com/" + strings.Repeat("a", 44) + "#", expectedErr: domain.ErrURLTooLong},
+		{url: "http://127.0.0.1:8080/admin", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://169.254.169.254/latest/meta-data", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://10.1.2.3/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://192.168.0.1/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://100.64.0.1/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://0.0.0.0/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://[::1]/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://[::ffff:127.0.0.1]/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://[fe80::1]/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://2130706433/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://0x7f.1/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://0177.0.0.1/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://localhost:3000/", expectedErr: domain.ErrPrivateAddress},
+		{url: "http://user@127.0.0.1/", expectedErr: domain.ErrPrivateAddress},
+		{url: "https://sho.rt/abc123", expectedErr: domain.ErrShortenerURL},
+		{url: "https://WWW.Bit.ly./abc", expectedErr: domain.ErrShortenerURL},
+		{url: "https://notbit.ly/abc", expectedErr: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			destination, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("url.Parse() error = %v", err)
+			}
+
+			if err := policy.Check(context.Background(), tt.url, destination); err != tt.expectedErr {
+				t.Errorf("Check() error = %v; want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
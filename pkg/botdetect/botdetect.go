@@ -0,0 +1,61 @@
+package botdetect
+
+import "strings"
+
+// DefaultPatterns matches the user agents of common crawlers, link preview services and security scanners.
+var DefaultPatterns = []string{
+	"bot",
+	"crawler",
+	"spider",
+	"slurp",
+	"facebookexternalhit",
+	"facebookcatalog",
+	"whatsapp",
+	"skypeuripreview",
+	"embedly",
+	"preview",
+	"scanner",
+	"headlesschrome",
+	"lighthouse",
+}
+
+// prefetchPurposes are the values of the Purpose, Sec-Purpose and X-Purpose headers
+// sent by browsers which load a page speculatively, without a user click.
+var prefetchPurposes = []string{"prefetch", "preview", "prerender"}
+
+type Detector struct {
+	patterns []string
+}
+
+// New returns a detector matching the case-insensitive user agent substrings, empty patterns are ignored.
+func New(patterns []string) *Detector {
+	d := &Detector{patterns: make([]string, 0, len(patterns))}
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p != "" {
+			d.patterns = append(d.patterns, p)
+		}
+	}
+
+	return d
+}
+
+// IsBot reports if the request was made by an automated client or a prefetch.
+// The purpose is the value of the Purpose, Sec-Purpose or X-Purpose request header.
+func (d *Detector) IsBot(userAgent string, purpose string) bool {
+	purpose = strings.ToLower(purpose)
+	for _, p := range prefetchPurposes {
+		if strings.Contains(purpose, p) {
+			return true
+		}
+	}
+
+	ua := strings.ToLower(userAgent)
+	for _, p := range d.patterns {
+		if strings.Contains(ua, p) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package botdetect_test
+
+import (
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/pkg/botdetect"
+)
+
+func Test_IsBot(t *testing.T) {
+	detector := botdetect.New(botdetect.DefaultPatterns)
+
+	tests := []struct {
+		userAgent string
+		purpose   string
+		want      bool
+	}{
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0", "", false},
+		{"Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)", "", true},
+		{"Twitterbot/1.0", "", true},
+		{"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", "", true},
+		{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36", "prefetch", true},
+		{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36", "prefetch;prerender", true},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := detector.IsBot(tt.userAgent, tt.purpose); got != tt.want {
+			t.Errorf("IsBot(%q, %q) = %v; want %v", tt.userAgent, tt.purpose, got, tt.want)
+		}
+	}
+}
+
+func Test_IsBotCustomPatterns(t *testing.T) {
+	detector := botdetect.New([]string{" Acme-Monitor ", ""})
+
+	if !detector.IsBot("acme-monitor/2.0", "") {
+		t.Errorf("IsBot() = false for a custom pattern; want true")
+	}
+	if detector.IsBot("Twitterbot/1.0", "") {
+		t.Errorf("IsBot() = true for a default pattern which was replaced; want false")
+	}
+}
@@ -0,0 +1,38 @@
+package clientip
+
+import (
+	"net"
+	"strings"
+)
+
+// proxyHeaders are checked in order, the same for the HTTP headers and the gRPC metadata.
+var proxyHeaders = []string{
+	// Cloudflare header
+	"CF-Connecting-IP",
+	// Standard header, the client is followed by the proxies
+	"X-Forwarded-For",
+	"X-Real-IP",
+}
+
+// FromProxyHeaders returns the client IP set by a reverse proxy, or "" when no proxy header is set.
+// get returns the first value of a header.
+func FromProxyHeaders(get func(name string) string) string {
+	for _, name := range proxyHeaders {
+		if value := get(name); value != "" {
+			ip, _, _ := strings.Cut(value, ",")
+			return strings.TrimSpace(ip)
+		}
+	}
+
+	return ""
+}
+
+// FromRemoteAddr returns the IP of a "host:port" address, or the address itself without a port.
+func FromRemoteAddr(addr string) string {
+	ip, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return ip
+}
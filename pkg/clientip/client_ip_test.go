@@ -0,0 +1,58 @@
+package clientip_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/pkg/clientip"
+)
+
+func Test_FromProxyHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"No Headers", map[string]string{}, ""},
+		{"Cloudflare", map[string]string{"CF-Connecting-IP": " 203.0.113.1 "}, "203.0.113.1"},
+		{"Forwarded For", map[string]string{"X-Forwarded-For": "203.0.113.2, 10.0.0.1"}, "203.0.113.2"},
+		{"Real IP", map[string]string{"X-Real-IP": "203.0.113.3"}, "203.0.113.3"},
+		{
+			"Cloudflare First",
+			map[string]string{"CF-Connecting-IP": "203.0.113.1", "X-Forwarded-For": "203.0.113.2", "X-Real-IP": "203.0.113.3"},
+			"203.0.113.1",
+		},
+		{"Forwarded For Before Real IP", map[string]string{"X-Forwarded-For": "203.0.113.2", "X-Real-IP": "203.0.113.3"}, "203.0.113.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for name, value := range tt.headers {
+				header.Set(name, value)
+			}
+
+			if got := clientip.FromProxyHeaders(header.Get); got != tt.want {
+				t.Errorf("FromProxyHeaders(%v) = %q; want %q", tt.headers, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_FromRemoteAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"203.0.113.1:54321", "203.0.113.1"},
+		{"[2001:db8::1]:54321", "2001:db8::1"},
+		{"203.0.113.1", "203.0.113.1"},
+		{"@", "@"},
+	}
+
+	for _, tt := range tests {
+		if got := clientip.FromRemoteAddr(tt.addr); got != tt.want {
+			t.Errorf("FromRemoteAddr(%q) = %q; want %q", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package domainlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Action int
+
+const (
+	// NoMatch means no rule covers the host
+	NoMatch Action = iota
+	Allow
+	Deny
+)
+
+// wildcard is the rule matching every host, it has the lowest precedence.
+const wildcard = "*"
+
+// List holds allow and deny rules of domains. A rule matches the domain and all its subdomains,
+// and the rule of the longest matching domain wins, so an allowed subdomain of a denied domain is allowed.
+type List struct {
+	rules map[string]Action
+}
+
+// Parse reads one rule per line: "allow <domain>", "deny <domain>" or a bare "<domain>", which is denied,
+// so plain blocklist feeds can be used as is. Empty lines and lines starting with "#" are ignored.
+// The domain "*" matches every host, "deny *" turns the list into an allowlist.
+func Parse(r io.Reader) (*List, error) {
+	l := &List{rules: map[string]Action{}}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		action, domain := Deny, ""
+		switch {
+		case len(fields) == 1:
+			domain = fields[0]
+		case len(fields) == 2 && strings.EqualFold(fields[0], "allow"):
+			action, domain = Allow, fields[1]
+		case len(fields) == 2 && strings.EqualFold(fields[0], "deny"):
+			domain = fields[1]
+		default:
+			return nil, fmt.Errorf("line %d: expected \"allow <domain>\" or \"deny <domain>\"", lineNumber)
+		}
+
+		l.rules[normalize(domain)] = action
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+
+	return l, nil
+}
+
+// Len returns the number of rules.
+func (l *List) Len() int {
+	return len(l.rules)
+}
+
+// Match returns the action of the most specific rule covering the host.
+func (l *List) Match(host string) Action {
+	host = normalize(host)
+
+	for domain := host; domain != ""; {
+		if action, ok := l.rules[domain]; ok {
+			return action
+		}
+
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			break
+		}
+		domain = parent
+	}
+
+	if action, ok := l.rules[wildcard]; ok {
+		return action
+	}
+
+	return NoMatch
+}
+
+// normalize lowercases the domain and removes the trailing dot of fully qualified names.
+func normalize(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
@@ -0,0 +1,60 @@
+package domainlist_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/pkg/domainlist"
+)
+
+func Test_Match(t *testing.T) {
+	list, err := domainlist.Parse(strings.NewReader(`
+# phishing feed
+evil.example
+deny Example.com
+allow docs.example.com
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		host string
+		want domainlist.Action
+	}{
+		{"evil.example", domainlist.Deny},
+		{"login.evil.example", domainlist.Deny},
+		{"example.com", domainlist.Deny},
+		{"www.EXAMPLE.com.", domainlist.Deny},
+		{"docs.example.com", domainlist.Allow},
+		{"api.docs.example.com", domainlist.Allow},
+		{"notexample.com", domainlist.NoMatch},
+		{"golang.org", domainlist.NoMatch},
+	}
+
+	for _, tt := range tests {
+		if got := list.Match(tt.host); got != tt.want {
+			t.Errorf("Match(%q) = %v; want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func Test_MatchWildcard(t *testing.T) {
+	list, err := domainlist.Parse(strings.NewReader("deny *\nallow example.com\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got := list.Match("www.example.com"); got != domainlist.Allow {
+		t.Errorf("Match(allowed host) = %v; want %v", got, domainlist.Allow)
+	}
+	if got := list.Match("golang.org"); got != domainlist.Deny {
+		t.Errorf("Match(other host) = %v; want %v", got, domainlist.Deny)
+	}
+}
+
+func Test_ParseInvalidLine(t *testing.T) {
+	if _, err := domainlist.Parse(strings.NewReader("block example.com")); err == nil {
+		t.Error("Parse() error = nil; want an error")
+	}
+}
@@ -0,0 +1,230 @@
+package qrimage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Level is the error correction level, a higher level survives more damage but needs more modules.
+type Level int
+
+const (
+	// Recovers about 7% of the modules
+	LevelLow Level = iota
+	// Recovers about 15% of the modules
+	LevelMedium
+	// Recovers about 25% of the modules
+	LevelQuartile
+	// Recovers about 30% of the modules
+	LevelHigh
+)
+
+// logoRatio is the side of the logo relative to the side of the code, small enough
+// for the hidden modules to be recovered at the quartile and high levels.
+const logoRatio = 0.2
+
+// ErrTooSmall is returned when the image is smaller than one pixel per module.
+var ErrTooSmall = errors.New("image too small for the code")
+
+type Options struct {
+	// Width and height of the image, in pixels
+	Size int
+	// Quiet zone around the code, in modules
+	Margin     int
+	Level      Level
+	Foreground color.RGBA
+	Background color.RGBA
+	// Draw the logo of the renderer in the middle of the code
+	Logo bool
+}
+
+type Renderer struct {
+	logo image.Image
+	// the logo encoded once, for the SVG data URI
+	logoPNG []byte
+}
+
+// New returns a renderer drawing the optional logo in the codes which ask for it.
+func New(logo image.Image) (*Renderer, error) {
+	r := &Renderer{logo: logo}
+	if logo != nil {
+		buf := new(bytes.Buffer)
+		if err := png.Encode(buf, logo); err != nil {
+			return nil, fmt.Errorf("encode logo: %w", err)
+		}
+		r.logoPNG = buf.Bytes()
+	}
+
+	return r, nil
+}
+
+// HasLogo reports if the codes may embed a logo.
+func (r *Renderer) HasLogo() bool {
+	return r.logo != nil
+}
+
+// PNG renders the code of the content as a PNG image.
+func (r *Renderer) PNG(content string, options Options) ([]byte, error) {
+	modules, err := encode(content, options)
+	if err != nil {
+		return nil, err
+	}
+
+	size := options.Size
+	total := len(modules) + 2*options.Margin
+
+	// two colors fit a paletted image, which is encoded much smaller
+	var img draw.Image = image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{options.Background, options.Foreground})
+	if options.Logo && r.logo != nil {
+		img = image.NewRGBA(image.Rect(0, 0, size, size))
+	}
+
+	for y := range size {
+		moduleY := y*total/size - options.Margin
+		for x := range size {
+			moduleX := x*total/size - options.Margin
+			if isDark(modules, moduleX, moduleY) {
+				img.Set(x, y, options.Foreground)
+			} else {
+				img.Set(x, y, options.Background)
+			}
+		}
+	}
+
+	if options.Logo && r.logo != nil {
+		box := logoBox(size, len(modules), total)
+		draw.Draw(img, box, image.NewUniform(options.Background), image.Point{}, draw.Src)
+		drawScaled(img, box.Inset(box.Dx()/10), r.logo)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SVG renders the code of the content as an SVG image, one unit of the view box per module.
+func (r *Renderer) SVG(content string, options Options) ([]byte, error) {
+	modules, err := encode(content, options)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(modules) + 2*options.Margin
+
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		options.Size, options.Size, total, total)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="%s"/>`, total, total, hexColor(options.Background))
+	fmt.Fprintf(buf, `<path fill="%s" d="`, hexColor(options.Foreground))
+	// the runs of dark modules of a row are drawn as one rectangle
+	for y, row := range modules {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+
+			run := 1
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(buf, "M%d %dh%dv1h-%dz", x+options.Margin, y+options.Margin, run, run)
+			x += run - 1
+		}
+	}
+	buf.WriteString(`"/>`)
+
+	if options.Logo && r.logo != nil {
+		side := float64(len(modules)) * logoRatio
+		offset := float64(total)/2 - side/2
+		fmt.Fprintf(buf, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`, offset, offset, side, side, hexColor(options.Background))
+		fmt.Fprintf(buf, `<image x="%g" y="%g" width="%g" height="%g" href="data:image/png;base64,%s"/>`,
+			offset+side/10, offset+side/10, side*0.8, side*0.8, base64.StdEncoding.EncodeToString(r.logoPNG))
+	}
+	buf.WriteString(`</svg>`)
+
+	return []byte(buf.String()), nil
+}
+
+// encode returns the modules of the code without the quiet zone, true for the dark ones.
+func encode(content string, options Options) ([][]bool, error) {
+	code, err := qrcode.New(content, recoveryLevel(options.Level))
+	if err != nil {
+		return nil, fmt.Errorf("encode content: %w", err)
+	}
+	code.DisableBorder = true
+
+	modules := code.Bitmap()
+	if options.Size < len(modules)+2*options.Margin {
+		return nil, ErrTooSmall
+	}
+
+	return modules, nil
+}
+
+func recoveryLevel(level Level) qrcode.RecoveryLevel {
+	switch level {
+	case LevelMedium:
+		return qrcode.Medium
+	case LevelQuartile:
+		return qrcode.High
+	case LevelHigh:
+		return qrcode.Highest
+	default:
+		return qrcode.Low
+	}
+}
+
+func isDark(modules [][]bool, x int, y int) bool {
+	if y < 0 || y >= len(modules) || x < 0 || x >= len(modules[y]) {
+		return false
+	}
+
+	return modules[y][x]
+}
+
+// logoBox returns the square in the middle of the image covered by the logo.
+func logoBox(size int, codeModules int, total int) image.Rectangle {
+	side := int(float64(codeModules*size) / float64(total) * logoRatio)
+	offset := (size - side) / 2
+
+	return image.Rect(offset, offset, offset+side, offset+side)
+}
+
+// drawScaled draws the image into the box with the nearest neighbour scaling, keeping its aspect ratio.
+func drawScaled(dst draw.Image, box image.Rectangle, src image.Image) {
+	bounds := src.Bounds()
+	if bounds.Empty() || box.Empty() {
+		return
+	}
+
+	scale := min(float64(box.Dx())/float64(bounds.Dx()), float64(box.Dy())/float64(bounds.Dy()))
+	width, height := int(float64(bounds.Dx())*scale), int(float64(bounds.Dy())*scale)
+	origin := image.Pt(box.Min.X+(box.Dx()-width)/2, box.Min.Y+(box.Dy()-height)/2)
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		srcY := bounds.Min.Y + int(float64(y)/scale)
+		for x := range width {
+			scaled.Set(x, y, src.At(bounds.Min.X+int(float64(x)/scale), srcY))
+		}
+	}
+
+	// the transparent parts of the logo keep the background
+	draw.Draw(dst, image.Rectangle{Min: origin, Max: origin.Add(image.Pt(width, height))}, scaled, image.Point{}, draw.Over)
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,81 @@
+package qrimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/pkg/qrimage"
+)
+
+var (
+	black = color.RGBA{A: 255}
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+func TestPNG(t *testing.T) {
+	renderer, err := qrimage.New(nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	data, err := renderer.PNG("https://sho.rt/XcNRfg", qrimage.Options{Size: 256, Margin: 4, Foreground: black, Background: white})
+	if err != nil {
+		t.Fatalf("PNG() error = %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(256, 256) {
+		t.Errorf("PNG() size = %v; want 256x256", got)
+	}
+	// the quiet zone is light and the finder pattern in the top left corner starts with a dark module
+	if r, _, _, _ := img.At(0, 0).RGBA(); r != 0xffff {
+		t.Errorf("PNG() margin is not the background color")
+	}
+	if r, _, _, _ := img.At(35, 35).RGBA(); r != 0 {
+		t.Errorf("PNG() finder pattern is not the foreground color")
+	}
+}
+
+func TestPNGTooSmall(t *testing.T) {
+	renderer, _ := qrimage.New(nil)
+
+	_, err := renderer.PNG("https://sho.rt/XcNRfg", qrimage.Options{Size: 20, Margin: 4, Foreground: black, Background: white})
+	if err != qrimage.ErrTooSmall {
+		t.Errorf("PNG() error = %v; want %v", err, qrimage.ErrTooSmall)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	logo := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	renderer, err := qrimage.New(logo)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	options := qrimage.Options{
+		Size:       300,
+		Margin:     2,
+		Level:      qrimage.LevelHigh,
+		Foreground: color.RGBA{R: 0x11, G: 0x22, B: 0x33, A: 255},
+		Background: white,
+		Logo:       true,
+	}
+	data, err := renderer.SVG("https://sho.rt/XcNRfg", options)
+	if err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+
+	svg := string(data)
+	for _, want := range []string{`width="300"`, `fill="#112233"`, `fill="#ffffff"`, `href="data:image/png;base64,`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG() does not contain %s", want)
+		}
+	}
+}
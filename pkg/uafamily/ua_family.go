@@ -0,0 +1,54 @@
+package uafamily
+
+import "strings"
+
+const (
+	Unknown = "Unknown"
+	Other   = "Other"
+)
+
+// families is checked in order, so the more specific tokens go first:
+// e.g. Edge and Opera user agents also contain "Chrome/" and "Safari/".
+var families = []struct {
+	token  string
+	family string
+}{
+	{"bot", "Bot"},
+	{"crawler", "Bot"},
+	{"spider", "Bot"},
+	{"edg/", "Edge"},
+	{"edga/", "Edge"},
+	{"edgios/", "Edge"},
+	{"opr/", "Opera"},
+	{"opera", "Opera"},
+	{"samsungbrowser/", "Samsung Internet"},
+	{"yabrowser/", "Yandex Browser"},
+	{"crios/", "Chrome"},
+	{"chrome/", "Chrome"},
+	{"chromium/", "Chrome"},
+	{"fxios/", "Firefox"},
+	{"firefox/", "Firefox"},
+	{"safari/", "Safari"},
+	{"curl/", "curl"},
+	{"wget/", "Wget"},
+	{"python-requests/", "Python"},
+	{"python-urllib/", "Python"},
+	{"go-http-client/", "Go"},
+	{"postmanruntime/", "Postman"},
+}
+
+// Family returns a coarse client family of the user agent, e.g. "Chrome" or "curl".
+func Family(userAgent string) string {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return Unknown
+	}
+
+	for _, f := range families {
+		if strings.Contains(ua, f.token) {
+			return f.family
+		}
+	}
+
+	return Other
+}
@@ -0,0 +1,29 @@
+package uafamily_test
+
+import (
+	"testing"
+
+	"github.com/OsoianMarcel/url-shortener/pkg/uafamily"
+)
+
+func Test_Family(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36", "Chrome"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51", "Edge"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0", "Firefox"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1", "Safari"},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", "Bot"},
+		{"curl/8.5.0", "curl"},
+		{"", "Unknown"},
+		{"SomethingElse/1.0", "Other"},
+	}
+
+	for _, tt := range tests {
+		if got := uafamily.Family(tt.userAgent); got != tt.want {
+			t.Errorf("Family(%q) = %q; want %q", tt.userAgent, got, tt.want)
+		}
+	}
+}